@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/diegok/pixpong/internal/config"
+	"github.com/diegok/pixpong/internal/history"
+)
+
+// runLeaderboard prints the standings from the match history
+func runLeaderboard(args []string) error {
+	cfg, err := config.ParseLeaderboardArgs(args)
+	if err != nil {
+		return err
+	}
+
+	matches, skipped, err := history.Load(cfg.HistoryFile)
+	if err != nil {
+		return err
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Damaged lines skipped in %s: %d\n", cfg.HistoryFile, skipped)
+	}
+	standings := history.Leaderboard(matches)
+	if len(standings) == 0 {
+		fmt.Printf("No matches played yet (%s)\n", cfg.HistoryFile)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tPlayer\tPlayed\tWins\tLosses\tWin rate\tStreak\tBest streak")
+	for i, st := range standings {
+		if i >= cfg.Top {
+			break
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t%.0f%%\t%s\t%d\n",
+			i+1, st.Name, st.Played(), st.Wins, st.Losses, st.WinRate()*100, history.StreakText(st.Streak), st.BestStreak)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d matches in %s\n", len(matches), cfg.HistoryFile)
+	return nil
+}
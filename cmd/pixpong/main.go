@@ -10,6 +10,14 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "leaderboard" {
+		if err := runLeaderboard(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	cfg, err := config.ParseArgs(os.Args[1:])
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -33,14 +41,43 @@ func printUsage() {
 	fmt.Fprintln(os.Stderr, "Usage:")
 	fmt.Fprintln(os.Stderr, "  pixpong --server [options]       Start a game server")
 	fmt.Fprintln(os.Stderr, "  pixpong --join <address>         Join a game server")
+	fmt.Fprintln(os.Stderr, "  pixpong --solo [options]         Play offline against the CPU")
+	fmt.Fprintln(os.Stderr, "  pixpong leaderboard [--top <n>]  Show wins, losses and streaks per player")
 	fmt.Fprintln(os.Stderr, "")
 	fmt.Fprintln(os.Stderr, "Options:")
 	fmt.Fprintln(os.Stderr, "  --port <port>       Server port (default: 5555)")
 	fmt.Fprintln(os.Stderr, "  --name <name>       Player name")
 	fmt.Fprintln(os.Stderr, "  --points <n>        Points to win (default: 10)")
+	fmt.Fprintln(os.Stderr, "  --bots <n>          Add bot players (default: 0, 1 in solo mode)")
+	fmt.Fprintln(os.Stderr, "  --difficulty <lvl>  Bot difficulty: easy, normal, hard (default: normal)")
+	fmt.Fprintln(os.Stderr, "  --powerups          Spawn power-ups on the court")
+	fmt.Fprintln(os.Stderr, "  --balls <n>         Balls in play on each serve, 1-5 (default: 1)")
+	fmt.Fprintln(os.Stderr, "  --ball-every <n>    Add a ball every N paddle hits (default: 0, never)")
+	fmt.Fprintln(os.Stderr, "  --time-limit <d>    Match time limit, e.g. 5m (default: 0, no limit)")
+	fmt.Fprintln(os.Stderr, "  --win-by-two        A game needs a two-point lead to be won")
+	fmt.Fprintln(os.Stderr, "  --sets <n>          Best of N sets, odd number (default: 1)")
+	fmt.Fprintln(os.Stderr, "  --ratings <file>    Player ratings file (default: ratings.json in the user config dir)")
+	fmt.Fprintln(os.Stderr, "  --history <file>    Match history file (default: history.jsonl in the user config dir)")
+	fmt.Fprintln(os.Stderr, "  --map <file>        Court layout file (JSON)")
+	fmt.Fprintln(os.Stderr, "  --court <WxH>       Fixed court size, e.g. 80x24 (default: fit the smallest terminal)")
+	fmt.Fprintln(os.Stderr, "  --resolution <mode> Draw balls and paddles finer than a cell: cell, half or braille (default: cell)")
+	fmt.Fprintln(os.Stderr, "  --no-effects        Turn off ball trails and hit effects")
+	fmt.Fprintln(os.Stderr, "  --theme <name|file> Color theme: classic, colorblind, contrast or a JSON theme file")
+	fmt.Fprintln(os.Stderr, "  --ascii             Draw with plain ASCII characters only (automatic without Unicode)")
+	fmt.Fprintln(os.Stderr, "  --four-way          Four teams, one on each edge of the court")
+	fmt.Fprintln(os.Stderr, "  --lateral <mode>    Paddles move toward the net: off, half, lane (default: off)")
+	fmt.Fprintln(os.Stderr, "  --rules <rules>     Rules preset (classic, fast, chill, wild) or rules file (JSON)")
+	fmt.Fprintln(os.Stderr, "  --serve <rule>      Who serves: standard, conceding, alternate, rotation (default: standard)")
+	fmt.Fprintln(os.Stderr, "  --serve-time <d>    Serve automatically after this long, 0 to wait forever (default: 10s)")
+	fmt.Fprintln(os.Stderr, "  --pauses <n>        Pauses each team may call per match (default: 2)")
+	fmt.Fprintln(os.Stderr, "  --tournament <fmt>  Run a tournament: single or double elimination")
+	fmt.Fprintln(os.Stderr, "  --team-size <n>     Players per tournament entrant (default: 1)")
+	fmt.Fprintln(os.Stderr, "  --king <n>          Winner stays on, n players per side and the rest wait in line")
 	fmt.Fprintln(os.Stderr, "")
 	fmt.Fprintln(os.Stderr, "Examples:")
 	fmt.Fprintln(os.Stderr, "  pixpong --server --name Host")
+	fmt.Fprintln(os.Stderr, "  pixpong --server --name Host --bots 1 --difficulty hard")
+	fmt.Fprintln(os.Stderr, "  pixpong --solo --difficulty easy")
 	fmt.Fprintln(os.Stderr, "  pixpong --join 192.168.1.100 --name Player2")
 	fmt.Fprintln(os.Stderr, "  pixpong --join localhost:5555 --name TestPlayer")
 }
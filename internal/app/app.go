@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -43,6 +44,7 @@ type App struct {
 	overState       protocol.GameOverState
 	rematchState    protocol.RematchState
 	countdown       int
+	lobbyCursor     int // Player selected by the host in the lobby
 
 	quit    chan struct{}
 	sigChan chan os.Signal
@@ -62,6 +64,16 @@ func (a *App) Run() error {
 	// Initialize audio (ignore errors - game works without sound)
 	_ = audio.Init()
 
+	// Pick the colors before the screen takes over the terminal, so a bad
+	// theme file is reported normally
+	if a.cfg.Theme != "" {
+		theme, err := ui.LoadTheme(a.cfg.Theme)
+		if err != nil {
+			return fmt.Errorf("failed to load theme: %w", err)
+		}
+		ui.UseTheme(theme)
+	}
+
 	// Initialize screen
 	screen, err := ui.InitScreen()
 	if err != nil {
@@ -70,6 +82,19 @@ func (a *App) Run() error {
 	a.screen = screen
 	a.renderer = ui.NewRenderer(screen)
 
+	// Serial consoles and old terminals get plain ASCII
+	if a.cfg.ASCII || !ui.UnicodeGlyphs().CanDisplay(screen) {
+		ui.UseGlyphs(ui.ASCIIGlyphs())
+	}
+
+	// Falls back to a coarser resolution if the terminal lacks the glyphs
+	resolution, err := ui.ParseResolution(a.cfg.Resolution)
+	if err != nil {
+		resolution = ui.CellResolution
+	}
+	a.renderer.SetResolution(resolution)
+	a.renderer.SetEffects(a.cfg.Effects)
+
 	// Setup signal handling
 	a.sigChan = make(chan os.Signal, 1)
 	signal.Notify(a.sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -82,10 +107,12 @@ func (a *App) Run() error {
 	// Get terminal size
 	w, h := a.screen.Size()
 
-	// Run as server or client
+	// Run as server, solo or client
 	var runErr error
 	if a.cfg.IsServer {
 		runErr = a.runServer(w, h)
+	} else if a.cfg.IsSolo {
+		runErr = a.runSolo(w, h)
 	} else {
 		runErr = a.runClient(w, h)
 	}
@@ -99,7 +126,16 @@ func (a *App) Run() error {
 // runServer creates and starts a server, then connects to it as a client.
 func (a *App) runServer(w, h int) error {
 	// Create and start server
-	a.server = server.NewServer(a.cfg)
+	var err error
+	if a.server, err = server.NewServer(a.cfg); err != nil {
+		return fmt.Errorf("failed to create server: %w", err)
+	}
+	if err := a.server.LoadMap(a.cfg.MapFile); err != nil {
+		return fmt.Errorf("failed to load map: %w", err)
+	}
+	if err := a.server.LoadRules(a.cfg.Rules); err != nil {
+		return fmt.Errorf("failed to load rules: %w", err)
+	}
 	if err := a.server.Start(); err != nil {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
@@ -119,19 +155,39 @@ func (a *App) runClient(w, h int) error {
 	return a.connectAndRun(addr, w, h)
 }
 
+// runSolo plays offline against the CPU. The server runs in-process and the
+// client talks to it over an in-memory connection instead of TCP.
+func (a *App) runSolo(w, h int) error {
+	var err error
+	if a.server, err = server.NewServer(a.cfg); err != nil {
+		return fmt.Errorf("failed to create server: %w", err)
+	}
+	if err := a.server.LoadMap(a.cfg.MapFile); err != nil {
+		return fmt.Errorf("failed to load map: %w", err)
+	}
+	if err := a.server.LoadRules(a.cfg.Rules); err != nil {
+		return fmt.Errorf("failed to load rules: %w", err)
+	}
+
+	a.client = client.NewClient(a.playerName(), w, h)
+	if err := a.client.ConnectConn(a.server.ConnectLocal()); err != nil {
+		return fmt.Errorf("failed to start solo game: %w", err)
+	}
+
+	// Nobody else is coming, so go straight to the countdown
+	a.inLobby = true
+	go a.server.StartGameWithCountdown()
+
+	return a.mainLoop()
+}
+
 // connectAndRun establishes a connection to the server and runs the main loop.
 func (a *App) connectAndRun(addr string, w, h int) error {
 	// Show connecting screen
 	a.renderer.RenderConnecting(addr)
 
-	// Generate random name if not provided
-	name := a.cfg.PlayerName
-	if name == "" {
-		name = a.generateRandomName()
-	}
-
 	// Create and connect client
-	a.client = client.NewClient(name, w, h)
+	a.client = client.NewClient(a.playerName(), w, h)
 	if err := a.client.Connect(addr); err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
@@ -145,6 +201,9 @@ func (a *App) connectAndRun(addr string, w, h int) error {
 
 // mainLoop is the main event loop that handles all input and state updates.
 func (a *App) mainLoop() error {
+	// The renderer marks this player's paddle on the court
+	a.renderer.SetPlayerID(a.client.PlayerID)
+
 	// Create event channel for screen events
 	events := make(chan tcell.Event)
 	go func() {
@@ -177,6 +236,12 @@ func (a *App) mainLoop() error {
 
 		case state := <-a.client.LobbyState:
 			a.lobbyState = state
+			if a.lobbyCursor >= len(state.Players) {
+				a.lobbyCursor = len(state.Players) - 1
+			}
+			if a.lobbyCursor < 0 {
+				a.lobbyCursor = 0
+			}
 			a.inLobby = true
 			a.inGame = false
 			a.gameOver = false
@@ -193,6 +258,7 @@ func (a *App) mainLoop() error {
 		case state := <-a.client.GameState:
 			// Detect sound events by comparing with previous state
 			a.detectSoundEvents(state)
+			a.renderer.UpdateEffects(state)
 			a.prevGameState = state
 			a.gameState = state
 			a.inGame = true
@@ -207,15 +273,17 @@ func (a *App) mainLoop() error {
 			a.inGame = false
 
 		case state := <-a.client.RematchState:
+			// The stats screen stays up until the player moves on
 			a.rematchState = state
 			a.inRematch = true
-			a.gameOver = false
 			a.inGame = false
 			a.inLobby = false
+			a.inCountdown = false
 
 		case countdown := <-a.client.Countdown:
 			a.countdown = countdown.Seconds
 			a.inCountdown = true
+			a.gameOver = false
 			a.inLobby = false
 			a.inGame = false
 
@@ -262,8 +330,10 @@ func (a *App) handleEvent(ev tcell.Event) bool {
 		}
 
 	case *tcell.EventResize:
-		// Handle resize by updating screen
+		// Redraw for the new size, and let the server size the next match by it
 		a.screen.Clear()
+		width, height := a.screen.Size()
+		a.client.SendResize(width, height)
 		a.render()
 	}
 
@@ -279,10 +349,101 @@ func (a *App) handleLobbyEvent(ev *tcell.EventKey) bool {
 				go a.server.StartGameWithCountdown()
 			}
 		}
+		return false
+	}
+
+	if a.lobbyState.IsHost && a.server != nil {
+		a.handleHostTeamEvent(ev)
+		return false
+	}
+
+	// Everyone else picks their own side
+	if pref, ok := teamKey(ev, a.lobbyState.FourWay); ok {
+		a.client.SendTeamChoice(pref)
 	}
 	return false
 }
 
+// handleHostTeamEvent lets the host move any player between teams.
+func (a *App) handleHostTeamEvent(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyUp:
+		if a.lobbyCursor > 0 {
+			a.lobbyCursor--
+		}
+		return
+	case tcell.KeyDown:
+		if a.lobbyCursor < len(a.lobbyState.Players)-1 {
+			a.lobbyCursor++
+		}
+		return
+	}
+
+	switch ev.Rune() {
+	case 'b', 'B':
+		go a.server.AutoBalance()
+		return
+	case 'e', 'E':
+		go a.server.BalanceByRating()
+		return
+	case 'l', 'L':
+		go a.server.ToggleTeamsLocked()
+		return
+	case 'p', 'P':
+		go a.server.CycleRules()
+		return
+	}
+
+	pref, ok := teamKey(ev, a.lobbyState.FourWay)
+	if !ok || a.lobbyCursor >= len(a.lobbyState.Players) {
+		return
+	}
+	player := a.lobbyState.Players[a.lobbyCursor]
+	id, err := strconv.Atoi(player.ID)
+	if err != nil {
+		return
+	}
+
+	// Team tournaments have numbered teams instead of sides. R leaves the
+	// player to fill any open place.
+	if a.lobbyState.Tournament != "" && a.lobbyState.TeamSize > 1 {
+		switch pref {
+		case protocol.PreferLeft:
+			go a.server.RegisterPlayer(id, max(0, player.Entrant-1))
+		case protocol.PreferRight:
+			go a.server.RegisterPlayer(id, player.Entrant+1)
+		case protocol.PreferRandom:
+			go a.server.RegisterPlayer(id, 0)
+		}
+		return
+	}
+	go a.server.MovePlayer(id, pref)
+}
+
+// teamKey maps the team selection keys to a preference. W and S pick the
+// top and bottom teams in four-way mode.
+func teamKey(ev *tcell.EventKey, fourWay bool) (protocol.TeamPreference, bool) {
+	switch ev.Key() {
+	case tcell.KeyLeft:
+		return protocol.PreferLeft, true
+	case tcell.KeyRight:
+		return protocol.PreferRight, true
+	}
+	switch ev.Rune() {
+	case 'a', 'A':
+		return protocol.PreferLeft, true
+	case 'd', 'D':
+		return protocol.PreferRight, true
+	case 'r', 'R':
+		return protocol.PreferRandom, true
+	case 'w', 'W':
+		return protocol.PreferTop, fourWay
+	case 's', 'S':
+		return protocol.PreferBottom, fourWay
+	}
+	return protocol.PreferRandom, false
+}
+
 // handleGameEvent handles events during gameplay.
 func (a *App) handleGameEvent(ev *tcell.EventKey) bool {
 	// Handle serve with Enter when waiting
@@ -291,33 +452,52 @@ func (a *App) handleGameEvent(ev *tcell.EventKey) bool {
 		return false
 	}
 
-	switch ev.Key() {
-	case tcell.KeyUp:
-		a.client.SendInput(protocol.DirUp)
-	case tcell.KeyDown:
-		a.client.SendInput(protocol.DirDown)
-	default:
-		switch ev.Rune() {
-		case 'w', 'W':
-			a.client.SendInput(protocol.DirUp)
-		case 's', 'S':
-			a.client.SendInput(protocol.DirDown)
-		}
+	// P pauses the match, or votes to resume it
+	if ev.Rune() == 'p' || ev.Rune() == 'P' {
+		a.client.SendPause()
+		return false
+	}
+
+	// N shows or hides the names next to the paddles
+	if ev.Rune() == 'n' || ev.Rune() == 'N' {
+		a.renderer.ToggleLabels()
+		return false
+	}
+
+	if dir := ui.KeyToDirection(ev.Key(), ev.Rune()); dir != protocol.DirNone {
+		a.client.SendInput(dir)
 	}
 	return false
 }
 
-// handleGameOverEvent handles events on the game over screen.
+// handleGameOverEvent handles events on the game over and stats screen.
 func (a *App) handleGameOverEvent(ev *tcell.EventKey) bool {
 	if ev.Key() == tcell.KeyEnter {
-		// Signal ready for rematch
-		a.client.SendRematchReady()
+		// Move on to the rematch vote
+		a.gameOver = false
 	}
 	return false
 }
 
 // handleRematchEvent handles events on the rematch screen.
 func (a *App) handleRematchEvent(ev *tcell.EventKey) bool {
+	if bracket := a.rematchState.Bracket; bracket != nil && bracket.Champion != "" {
+		// The champion screen waits for the host to close the tournament
+		if ev.Key() == tcell.KeyEnter && a.rematchState.IsHost && a.server != nil {
+			go a.server.EndTournament()
+		}
+		return false
+	}
+
+	// The bracket or the line decides the teams in a tournament or
+	// winner-stays-on
+	fixed := a.rematchState.Bracket != nil || a.rematchState.King > 0
+	if (ev.Rune() == 'r' || ev.Rune() == 'R') && !fixed {
+		// Ask for new random teams instead of keeping the last ones
+		a.client.SendReshuffle()
+		return false
+	}
+
 	if ev.Key() == tcell.KeyEnter {
 		if a.rematchState.IsHost && a.rematchState.AllReady {
 			// Host can start when all ready
@@ -334,10 +514,21 @@ func (a *App) handleRematchEvent(ev *tcell.EventKey) bool {
 
 // render calls the appropriate renderer method based on the current state.
 func (a *App) render() {
+	// Nothing fits on a terminal below the minimum, so say so instead
+	width, height := a.screen.Size()
+	if width < server.MinTermWidth || height < server.MinTermHeight {
+		a.renderer.RenderTooSmall(width, height, server.MinTermWidth, server.MinTermHeight)
+		return
+	}
+
 	if a.inCountdown {
 		a.renderer.RenderCountdown(a.countdown)
 	} else if a.inLobby {
-		a.renderer.RenderLobby(a.lobbyState)
+		cursor := -1
+		if a.lobbyState.IsHost {
+			cursor = a.lobbyCursor
+		}
+		a.renderer.RenderLobby(a.lobbyState, cursor)
 	} else if a.inPause {
 		a.renderer.RenderPause(a.pauseState)
 	} else if a.inGame {
@@ -358,16 +549,41 @@ func (a *App) detectSoundEvents(state protocol.GameState) {
 		return
 	}
 
-	// Detect paddle hit: ball horizontal velocity reversed (and ball is in play area)
-	if state.Ball.X > 0 && state.Ball.X < float64(state.CourtWidth) {
-		// VX sign changed = hit something
-		if (prev.Ball.VX > 0 && state.Ball.VX < 0) || (prev.Ball.VX < 0 && state.Ball.VX > 0) {
-			audio.PlayPaddleHit()
-		}
+	// Detect shield block: it also reverses the ball, so it replaces the paddle hit sound
+	shieldBlocked := state.ShieldBlocks > prev.ShieldBlocks
+	if shieldBlocked {
+		audio.PlayShieldBlock()
+	}
+
+	// Compare each ball with the same ball in the previous frame. When a ball
+	// leaves the court the list shifts, so skip that frame.
+	balls := append([]protocol.BallState{state.Ball}, state.ExtraBalls...)
+	prevBalls := append([]protocol.BallState{prev.Ball}, prev.ExtraBalls...)
+	if len(balls) != len(prevBalls) {
+		balls = balls[:0]
 	}
 
-	// Detect wall bounce: ball vertical velocity reversed
-	if (prev.Ball.VY > 0 && state.Ball.VY < 0) || (prev.Ball.VY < 0 && state.Ball.VY > 0) {
+	paddleHit, wallBounce := false, false
+	for i, ball := range balls {
+		prevBall := prevBalls[i]
+
+		// Detect paddle hit: ball horizontal velocity reversed (and ball is in play area)
+		if ball.X > 0 && ball.X < float64(state.CourtWidth) {
+			// VX sign changed = hit something
+			if (prevBall.VX > 0 && ball.VX < 0) || (prevBall.VX < 0 && ball.VX > 0) {
+				paddleHit = true
+			}
+		}
+
+		// Detect wall bounce: ball vertical velocity reversed
+		if (prevBall.VY > 0 && ball.VY < 0) || (prevBall.VY < 0 && ball.VY > 0) {
+			wallBounce = true
+		}
+	}
+	if paddleHit && !shieldBlocked {
+		audio.PlayPaddleHit()
+	}
+	if wallBounce {
 		audio.PlayWallBounce()
 	}
 
@@ -375,6 +591,29 @@ func (a *App) detectSoundEvents(state protocol.GameState) {
 	if state.LeftScore > prev.LeftScore || state.RightScore > prev.RightScore {
 		audio.PlayScore()
 	}
+
+	// Detect power-up taken
+	if state.PowerUpsCollected > prev.PowerUpsCollected {
+		audio.PlayPowerUp()
+	}
+
+	// Detect power-up spawn: an item we haven't seen before
+	for _, item := range state.PowerUps {
+		if !hasPowerUp(prev.PowerUps, item.ID) {
+			audio.PlayPowerUpSpawn()
+			break
+		}
+	}
+}
+
+// hasPowerUp returns true if the list contains the power-up with the given ID
+func hasPowerUp(items []protocol.PowerUpState, id int) bool {
+	for _, item := range items {
+		if item.ID == id {
+			return true
+		}
+	}
+	return false
 }
 
 // cleanup shuts down all resources.
@@ -406,6 +645,14 @@ func (a *App) hasPort(addr string) bool {
 	return strings.Contains(addr, ":")
 }
 
+// playerName returns the configured name, or a random one if not provided.
+func (a *App) playerName() string {
+	if a.cfg.PlayerName != "" {
+		return a.cfg.PlayerName
+	}
+	return a.generateRandomName()
+}
+
 // generateRandomName creates a random player name.
 func (a *App) generateRandomName() string {
 	adjectives := []string{"Swift", "Brave", "Quick", "Sharp", "Bold", "Cool", "Fast", "Keen"}
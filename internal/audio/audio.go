@@ -117,3 +117,38 @@ func PlayScore() {
 		speaker.Play(squareWave(330, 150*time.Millisecond))
 	}()
 }
+
+// PlayPowerUpSpawn plays the sound when a power-up appears on the court
+func PlayPowerUpSpawn() {
+	if !initialized {
+		return
+	}
+	// Soft sine blip so it doesn't compete with the hit sounds
+	speaker.Play(tone(1320, 60*time.Millisecond))
+}
+
+// PlayPowerUp plays the sound when the ball takes a power-up
+func PlayPowerUp() {
+	if !initialized {
+		return
+	}
+	// Rising arpeggio
+	go func() {
+		speaker.Play(squareWave(523, 60*time.Millisecond))
+		time.Sleep(60 * time.Millisecond)
+		speaker.Play(squareWave(659, 60*time.Millisecond))
+		time.Sleep(60 * time.Millisecond)
+		speaker.Play(squareWave(784, 60*time.Millisecond))
+		time.Sleep(60 * time.Millisecond)
+		speaker.Play(squareWave(1047, 90*time.Millisecond))
+	}()
+}
+
+// PlayShieldBlock plays the sound when a shield stops a goal
+func PlayShieldBlock() {
+	if !initialized {
+		return
+	}
+	// Low thud
+	speaker.Play(squareWave(220, 80*time.Millisecond))
+}
@@ -62,6 +62,13 @@ func (c *Client) Connect(addr string) error {
 		return fmt.Errorf("failed to connect to server: %w", err)
 	}
 
+	return c.ConnectConn(conn)
+}
+
+// ConnectConn joins the server over an already established connection,
+// such as the in-memory pipe used by solo mode.
+// It sends a JoinRequest and waits for a JoinResponse before returning.
+func (c *Client) ConnectConn(conn net.Conn) error {
 	c.conn = conn
 	c.codec = protocol.NewCodec(conn)
 
@@ -169,6 +176,75 @@ func (c *Client) SendServe() error {
 	return c.codec.Encode(&msg)
 }
 
+// SendPause asks the server to pause the match, or votes to resume it.
+func (c *Client) SendPause() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected {
+		return fmt.Errorf("not connected to server")
+	}
+
+	msg := protocol.Message{
+		Type:    protocol.MsgPause,
+		Payload: nil,
+	}
+	return c.codec.Encode(&msg)
+}
+
+// SendResize tells the server the new size of the player's terminal.
+func (c *Client) SendResize(width, height int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected {
+		return fmt.Errorf("not connected to server")
+	}
+
+	msg := protocol.Message{
+		Type: protocol.MsgResize,
+		Payload: protocol.Resize{
+			TerminalWidth:  width,
+			TerminalHeight: height,
+		},
+	}
+	return c.codec.Encode(&msg)
+}
+
+// SendTeamChoice sends the side the player wants to play on.
+func (c *Client) SendTeamChoice(pref protocol.TeamPreference) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected {
+		return fmt.Errorf("not connected to server")
+	}
+
+	msg := protocol.Message{
+		Type: protocol.MsgTeamChoice,
+		Payload: protocol.TeamChoice{
+			Preference: pref,
+		},
+	}
+	return c.codec.Encode(&msg)
+}
+
+// SendReshuffle asks the server for new random teams in the rematch.
+func (c *Client) SendReshuffle() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected {
+		return fmt.Errorf("not connected to server")
+	}
+
+	msg := protocol.Message{
+		Type:    protocol.MsgReshuffle,
+		Payload: nil,
+	}
+	return c.codec.Encode(&msg)
+}
+
 // Close closes the connection to the server.
 func (c *Client) Close() {
 	c.mu.Lock()
@@ -4,21 +4,65 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Default values for configuration
 const (
-	DefaultPort   = 5555
-	DefaultPoints = 10
+	DefaultPort       = 5555
+	DefaultPoints     = 10
+	DefaultDifficulty = "normal"
+	DefaultServeTime  = 10 * time.Second
+	DefaultPauses     = 2
+	MaxBots           = 8
+	MaxBalls          = 5
+	RatingsFileName   = "ratings.json"
+	HistoryFileName   = "history.jsonl"
+	DefaultTop        = 20
+	MinCourtWidth     = 40
+	MinCourtHeight    = 20
+	MaxCourtWidth     = 400
+	MaxCourtHeight    = 200
 )
 
 // Config holds the application configuration
 type Config struct {
 	IsServer    bool
+	IsSolo      bool
 	ServerAddr  string
 	Port        int
 	PointsToWin int
 	PlayerName  string
+	Bots        int
+	Difficulty  string
+	PowerUps    bool
+	Balls       int
+	BallEvery   int
+	TimeLimit   time.Duration
+	WinByTwo    bool
+	Sets        int
+	RatingsFile string
+	HistoryFile string
+	MapFile     string
+	FourWay     bool
+	Lateral     string
+	Rules       string // Preset name or rules file, empty for the classic rules
+	ServeRule   string
+	ServeTime   time.Duration
+	Pauses      int
+	Tournament  string // Bracket format, empty for normal matches
+	TeamSize    int    // Players per tournament entrant
+	King        int    // Players per side in winner-stays-on, 0 when off
+	CourtWidth  int    // Fixed court size, 0 to fit the smallest terminal
+	CourtHeight int
+	Resolution  string // How finely balls and paddles are drawn: cell, half or braille
+	Effects     bool   // Ball trails and hit effects
+	Theme       string // Preset name or theme file, empty for the classic colors
+	ASCII       bool   // Plain ASCII glyphs, also used when the terminal lacks Unicode
 }
 
 // ParseArgs parses command line arguments and returns a Config
@@ -27,9 +71,35 @@ func ParseArgs(args []string) (*Config, error) {
 
 	server := fs.Bool("server", false, "run as server")
 	join := fs.String("join", "", "server address to join")
+	solo := fs.Bool("solo", false, "play offline against the CPU")
 	port := fs.Int("port", DefaultPort, "port number (1-65535)")
 	points := fs.Int("points", DefaultPoints, "points to win (>=1)")
 	name := fs.String("name", "", "player name")
+	bots := fs.Int("bots", 0, "number of bot players (0-8)")
+	difficulty := fs.String("difficulty", DefaultDifficulty, "bot difficulty (easy, normal, hard)")
+	powerUps := fs.Bool("powerups", false, "spawn power-ups on the court")
+	balls := fs.Int("balls", 1, "balls in play on each serve (1-5)")
+	ballEvery := fs.Int("ball-every", 0, "add a ball every N paddle hits (0 = never)")
+	timeLimit := fs.Duration("time-limit", 0, "match time limit, e.g. 5m (0 = no limit)")
+	winByTwo := fs.Bool("win-by-two", false, "require a two-point lead to win")
+	sets := fs.Int("sets", 1, "best of N sets (odd number)")
+	ratingsFile := fs.String("ratings", "", "file to keep player ratings in")
+	historyFile := fs.String("history", "", "file to keep the match history in")
+	mapFile := fs.String("map", "", "court layout file (JSON)")
+	fourWay := fs.Bool("four-way", false, "four teams, one on each edge of the court")
+	lateral := fs.String("lateral", "off", "paddles move toward the net: off, half (team half) or lane (own lane)")
+	rules := fs.String("rules", "", "rules preset (classic, fast, chill, wild) or rules file (JSON)")
+	serveRule := fs.String("serve", "standard", "who serves: standard, conceding, alternate or rotation")
+	serveTime := fs.Duration("serve-time", DefaultServeTime, "serve automatically after this long (0 = wait forever)")
+	pauses := fs.Int("pauses", DefaultPauses, "pauses each team may call per match (0 = none)")
+	tournament := fs.String("tournament", "", "run a tournament: single or double elimination")
+	teamSize := fs.Int("team-size", 1, "players per tournament entrant (>=1)")
+	court := fs.String("court", "", "fixed court size as WIDTHxHEIGHT, e.g. 80x24 (default: fit the smallest terminal)")
+	resolution := fs.String("resolution", "cell", "draw balls and paddles finer than a cell: cell, half (half blocks) or braille")
+	theme := fs.String("theme", "", "color theme (classic, colorblind, contrast) or theme file (JSON)")
+	noEffects := fs.Bool("no-effects", false, "turn off ball trails and hit effects")
+	ascii := fs.Bool("ascii", false, "draw with plain ASCII characters only")
+	king := fs.Int("king", 0, "winner stays on with N players per side, the rest wait in line (0 = off)")
 
 	if err := fs.Parse(args); err != nil {
 		return nil, err
@@ -40,9 +110,14 @@ func ParseArgs(args []string) (*Config, error) {
 		return nil, errors.New("cannot specify both --server and --join")
 	}
 
-	// Validate: must have either --server or --join
-	if !*server && *join == "" {
-		return nil, errors.New("must specify either --server or --join")
+	// Validate: solo mode runs its own game, so it excludes the others
+	if *solo && (*server || *join != "") {
+		return nil, errors.New("cannot combine --solo with --server or --join")
+	}
+
+	// Validate: must have either --server, --join or --solo
+	if !*server && *join == "" && !*solo {
+		return nil, errors.New("must specify either --server, --join or --solo")
 	}
 
 	// Validate port range
@@ -55,13 +130,203 @@ func ParseArgs(args []string) (*Config, error) {
 		return nil, fmt.Errorf("points must be at least 1, got %d", *points)
 	}
 
+	// Validate bots
+	if *bots < 0 || *bots > MaxBots {
+		return nil, fmt.Errorf("bots must be between 0 and %d, got %d", MaxBots, *bots)
+	}
+
+	// Validate difficulty
+	switch *difficulty {
+	case "easy", "normal", "hard":
+	default:
+		return nil, fmt.Errorf("difficulty must be easy, normal or hard, got %q", *difficulty)
+	}
+
+	// Solo mode always needs someone to play against
+	if *solo && *bots == 0 {
+		*bots = 1
+	}
+
+	// Validate multi-ball
+	if *balls < 1 || *balls > MaxBalls {
+		return nil, fmt.Errorf("balls must be between 1 and %d, got %d", MaxBalls, *balls)
+	}
+	if *ballEvery < 0 {
+		return nil, fmt.Errorf("ball-every cannot be negative, got %d", *ballEvery)
+	}
+
+	// Validate match format
+	if *timeLimit < 0 {
+		return nil, fmt.Errorf("time-limit cannot be negative, got %s", *timeLimit)
+	}
+	if *sets < 1 || *sets%2 == 0 {
+		return nil, fmt.Errorf("sets must be a positive odd number, got %d", *sets)
+	}
+
+	// Validate lateral movement
+	switch *lateral {
+	case "off", "half", "lane":
+	default:
+		return nil, fmt.Errorf("lateral must be off, half or lane, got %q", *lateral)
+	}
+
+	// Validate serving
+	switch *serveRule {
+	case "standard", "conceding", "alternate", "rotation":
+	default:
+		return nil, fmt.Errorf("serve must be standard, conceding, alternate or rotation, got %q", *serveRule)
+	}
+	if *serveTime < 0 {
+		return nil, fmt.Errorf("serve-time cannot be negative, got %s", *serveTime)
+	}
+
+	// Validate pauses
+	if *pauses < 0 {
+		return nil, fmt.Errorf("pauses cannot be negative, got %d", *pauses)
+	}
+
+	// Validate tournament
+	switch *tournament {
+	case "", "single", "double":
+	default:
+		return nil, fmt.Errorf("tournament must be single or double, got %q", *tournament)
+	}
+	if *teamSize < 1 {
+		return nil, fmt.Errorf("team-size must be at least 1, got %d", *teamSize)
+	}
+	if *tournament != "" && *fourWay {
+		return nil, errors.New("cannot combine --tournament with --four-way")
+	}
+
+	// Validate court size
+	courtWidth, courtHeight, err := parseCourtSize(*court)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate resolution
+	switch *resolution {
+	case "cell", "half", "braille":
+	default:
+		return nil, fmt.Errorf("resolution must be cell, half or braille, got %q", *resolution)
+	}
+
+	// Validate winner stays on
+	if *king < 0 {
+		return nil, fmt.Errorf("king cannot be negative, got %d", *king)
+	}
+	if *king > 0 && (*tournament != "" || *fourWay) {
+		return nil, errors.New("cannot combine --king with --tournament or --four-way")
+	}
+
+	// Four-way matches are a single game to the points limit
+	if *fourWay && (*sets > 1 || *winByTwo || *timeLimit > 0) {
+		return nil, errors.New("cannot combine --four-way with --sets, --win-by-two or --time-limit")
+	}
+
+	// Keep ratings in the user's config directory unless told otherwise
+	if *ratingsFile == "" {
+		*ratingsFile = DataPath(RatingsFileName)
+	}
+	if *historyFile == "" {
+		*historyFile = DataPath(HistoryFileName)
+	}
+
 	cfg := &Config{
 		IsServer:    *server,
+		IsSolo:      *solo,
 		ServerAddr:  *join,
 		Port:        *port,
 		PointsToWin: *points,
 		PlayerName:  *name,
+		Bots:        *bots,
+		Difficulty:  *difficulty,
+		PowerUps:    *powerUps,
+		Balls:       *balls,
+		BallEvery:   *ballEvery,
+		TimeLimit:   *timeLimit,
+		WinByTwo:    *winByTwo,
+		Sets:        *sets,
+		RatingsFile: *ratingsFile,
+		HistoryFile: *historyFile,
+		MapFile:     *mapFile,
+		FourWay:     *fourWay,
+		Lateral:     *lateral,
+		Rules:       *rules,
+		ServeRule:   *serveRule,
+		ServeTime:   *serveTime,
+		Pauses:      *pauses,
+		Tournament:  *tournament,
+		TeamSize:    *teamSize,
+		King:        *king,
+		CourtWidth:  courtWidth,
+		CourtHeight: courtHeight,
+		Resolution:  *resolution,
+		Effects:     !*noEffects,
+		Theme:       *theme,
+		ASCII:       *ascii,
 	}
 
 	return cfg, nil
 }
+
+// parseCourtSize reads a WIDTHxHEIGHT court size. Empty means no fixed size.
+func parseCourtSize(size string) (int, int, error) {
+	if size == "" {
+		return 0, 0, nil
+	}
+
+	w, h, ok := strings.Cut(strings.ToLower(size), "x")
+	width, errW := strconv.Atoi(w)
+	height, errH := strconv.Atoi(h)
+	if !ok || errW != nil || errH != nil {
+		return 0, 0, fmt.Errorf("court must be WIDTHxHEIGHT, e.g. 80x24, got %q", size)
+	}
+	if width < MinCourtWidth || width > MaxCourtWidth || height < MinCourtHeight || height > MaxCourtHeight {
+		return 0, 0, fmt.Errorf("court must be between %dx%d and %dx%d, got %q",
+			MinCourtWidth, MinCourtHeight, MaxCourtWidth, MaxCourtHeight, size)
+	}
+	return width, height, nil
+}
+
+// LeaderboardConfig holds the options of the leaderboard command
+type LeaderboardConfig struct {
+	HistoryFile string
+	Top         int
+}
+
+// ParseLeaderboardArgs parses the arguments of the leaderboard command
+func ParseLeaderboardArgs(args []string) (*LeaderboardConfig, error) {
+	fs := flag.NewFlagSet("pixpong leaderboard", flag.ContinueOnError)
+
+	historyFile := fs.String("history", "", "match history file")
+	top := fs.Int("top", DefaultTop, "number of players to show (>=1)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	if *top < 1 {
+		return nil, fmt.Errorf("top must be at least 1, got %d", *top)
+	}
+	if *historyFile == "" {
+		*historyFile = DataPath(HistoryFileName)
+	}
+
+	return &LeaderboardConfig{
+		HistoryFile: *historyFile,
+		Top:         *top,
+	}, nil
+}
+
+// DataPath returns where a pixpong data file lives, inside the user's config
+// directory. Falls back to the current directory if there is none.
+func DataPath(name string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(dir, "pixpong", name)
+}
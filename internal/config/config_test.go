@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 )
 
 func TestParseArgs_ServerMode(t *testing.T) {
@@ -161,3 +162,502 @@ func TestDefaultConstants(t *testing.T) {
 		t.Errorf("expected DefaultPoints 10, got %d", DefaultPoints)
 	}
 }
+
+func TestParseArgs_Bots(t *testing.T) {
+	args := []string{"--server", "--bots", "3", "--difficulty", "hard"}
+	cfg, err := ParseArgs(args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Bots != 3 {
+		t.Errorf("expected 3 bots, got %d", cfg.Bots)
+	}
+	if cfg.Difficulty != "hard" {
+		t.Errorf("expected difficulty 'hard', got '%s'", cfg.Difficulty)
+	}
+}
+
+func TestParseArgs_BotsDefaults(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Bots != 0 {
+		t.Errorf("expected 0 bots, got %d", cfg.Bots)
+	}
+	if cfg.Difficulty != DefaultDifficulty {
+		t.Errorf("expected difficulty %q, got %q", DefaultDifficulty, cfg.Difficulty)
+	}
+}
+
+func TestParseArgs_InvalidBots(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"negative bots", []string{"--server", "--bots", "-1"}},
+		{"too many bots", []string{"--server", "--bots", "9"}},
+		{"unknown difficulty", []string{"--server", "--bots", "1", "--difficulty", "insane"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseArgs(tt.args); err == nil {
+				t.Errorf("expected error for %v", tt.args)
+			}
+		})
+	}
+}
+
+func TestParseArgs_SoloMode(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--solo", "--difficulty", "easy"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.IsSolo {
+		t.Error("expected IsSolo to be true")
+	}
+	if cfg.IsServer {
+		t.Error("expected IsServer to be false")
+	}
+	if cfg.Bots != 1 {
+		t.Errorf("expected solo mode to add 1 bot, got %d", cfg.Bots)
+	}
+	if cfg.Difficulty != "easy" {
+		t.Errorf("expected difficulty 'easy', got '%s'", cfg.Difficulty)
+	}
+}
+
+func TestParseArgs_SoloWithBots(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--solo", "--bots", "3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Bots != 3 {
+		t.Errorf("expected 3 bots, got %d", cfg.Bots)
+	}
+}
+
+func TestParseArgs_SoloExclusive(t *testing.T) {
+	if _, err := ParseArgs([]string{"--solo", "--server"}); err == nil {
+		t.Error("expected error when both --solo and --server specified")
+	}
+	if _, err := ParseArgs([]string{"--solo", "--join", "localhost"}); err == nil {
+		t.Error("expected error when both --solo and --join specified")
+	}
+}
+
+func TestParseArgs_PowerUps(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.PowerUps {
+		t.Error("expected power-ups to be off by default")
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--powerups"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.PowerUps {
+		t.Error("expected power-ups to be enabled")
+	}
+}
+
+func TestParseArgs_MultiBall(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Balls != 1 || cfg.BallEvery != 0 {
+		t.Errorf("expected 1 ball and no ball-every by default, got %d and %d", cfg.Balls, cfg.BallEvery)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--balls", "3", "--ball-every", "5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Balls != 3 {
+		t.Errorf("expected 3 balls, got %d", cfg.Balls)
+	}
+	if cfg.BallEvery != 5 {
+		t.Errorf("expected ball-every 5, got %d", cfg.BallEvery)
+	}
+
+	for _, args := range [][]string{
+		{"--server", "--balls", "0"},
+		{"--server", "--balls", "6"},
+		{"--server", "--ball-every", "-1"},
+	} {
+		if _, err := ParseArgs(args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestParseArgs_MatchFormat(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TimeLimit != 0 || cfg.WinByTwo || cfg.Sets != 1 {
+		t.Errorf("expected a plain single game by default, got %+v", cfg)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--time-limit", "5m", "--win-by-two", "--sets", "3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TimeLimit != 5*time.Minute {
+		t.Errorf("expected 5m time limit, got %s", cfg.TimeLimit)
+	}
+	if !cfg.WinByTwo {
+		t.Error("expected win-by-two to be enabled")
+	}
+	if cfg.Sets != 3 {
+		t.Errorf("expected 3 sets, got %d", cfg.Sets)
+	}
+
+	for _, args := range [][]string{
+		{"--server", "--time-limit", "-1m"},
+		{"--server", "--sets", "0"},
+		{"--server", "--sets", "2"},
+	} {
+		if _, err := ParseArgs(args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestParseArgs_RatingsFile(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RatingsFile != DataPath(RatingsFileName) {
+		t.Errorf("expected default ratings file %q, got %q", DataPath(RatingsFileName), cfg.RatingsFile)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--ratings", "/tmp/office.json"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.RatingsFile != "/tmp/office.json" {
+		t.Errorf("expected ratings file /tmp/office.json, got %q", cfg.RatingsFile)
+	}
+}
+
+func TestParseArgs_HistoryFile(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.HistoryFile != DataPath(HistoryFileName) {
+		t.Errorf("expected default history file %q, got %q", DataPath(HistoryFileName), cfg.HistoryFile)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--history", "/tmp/office.jsonl"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.HistoryFile != "/tmp/office.jsonl" {
+		t.Errorf("expected history file /tmp/office.jsonl, got %q", cfg.HistoryFile)
+	}
+}
+
+func TestParseLeaderboardArgs(t *testing.T) {
+	cfg, err := ParseLeaderboardArgs(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.HistoryFile != DataPath(HistoryFileName) || cfg.Top != DefaultTop {
+		t.Errorf("expected defaults, got %+v", cfg)
+	}
+
+	cfg, err = ParseLeaderboardArgs([]string{"--history", "/tmp/office.jsonl", "--top", "5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.HistoryFile != "/tmp/office.jsonl" || cfg.Top != 5 {
+		t.Errorf("expected custom options, got %+v", cfg)
+	}
+
+	for _, args := range [][]string{
+		{"--top", "0"},
+		{"extra"},
+	} {
+		if _, err := ParseLeaderboardArgs(args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestParseArgs_Map(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server", "--map", "maps/pillars.json"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MapFile != "maps/pillars.json" {
+		t.Errorf("expected map file maps/pillars.json, got %q", cfg.MapFile)
+	}
+}
+
+func TestParseArgs_FourWay(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server", "--four-way"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.FourWay {
+		t.Error("expected four-way mode to be enabled")
+	}
+
+	for _, args := range [][]string{
+		{"--server", "--four-way", "--sets", "3"},
+		{"--server", "--four-way", "--win-by-two"},
+		{"--server", "--four-way", "--time-limit", "5m"},
+	} {
+		if _, err := ParseArgs(args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestParseArgs_Lateral(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Lateral != "off" {
+		t.Errorf("expected lateral movement off by default, got %q", cfg.Lateral)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--lateral", "lane"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Lateral != "lane" {
+		t.Errorf("expected lateral mode lane, got %q", cfg.Lateral)
+	}
+
+	if _, err := ParseArgs([]string{"--server", "--lateral", "sideways"}); err == nil {
+		t.Error("expected error for unknown lateral mode")
+	}
+}
+
+func TestParseArgs_Serve(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ServeRule != "standard" || cfg.ServeTime != DefaultServeTime {
+		t.Errorf("expected standard serves with a %s clock, got %q %s", DefaultServeTime, cfg.ServeRule, cfg.ServeTime)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--serve", "rotation", "--serve-time", "0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ServeRule != "rotation" || cfg.ServeTime != 0 {
+		t.Errorf("expected rotation without a clock, got %q %s", cfg.ServeRule, cfg.ServeTime)
+	}
+
+	for _, args := range [][]string{
+		{"--server", "--serve", "random"},
+		{"--server", "--serve-time", "-5s"},
+	} {
+		if _, err := ParseArgs(args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestParseArgs_Pauses(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Pauses != DefaultPauses {
+		t.Errorf("expected %d pauses by default, got %d", DefaultPauses, cfg.Pauses)
+	}
+
+	if _, err := ParseArgs([]string{"--server", "--pauses", "-1"}); err == nil {
+		t.Error("expected error for negative pauses")
+	}
+}
+
+func TestParseArgs_Tournament(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Tournament != "" || cfg.TeamSize != 1 {
+		t.Errorf("expected no tournament with solo entrants by default, got %q and %d", cfg.Tournament, cfg.TeamSize)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--tournament", "double", "--team-size", "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Tournament != "double" || cfg.TeamSize != 2 {
+		t.Errorf("expected a double elimination for pairs, got %q and %d", cfg.Tournament, cfg.TeamSize)
+	}
+
+	for _, args := range [][]string{
+		{"--server", "--tournament", "swiss"},
+		{"--server", "--team-size", "0"},
+		{"--server", "--tournament", "single", "--four-way"},
+	} {
+		if _, err := ParseArgs(args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestParseArgs_King(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.King != 0 {
+		t.Errorf("expected winner stays on off by default, got %d", cfg.King)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--king", "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.King != 2 {
+		t.Errorf("expected 2 players per side, got %d", cfg.King)
+	}
+
+	for _, args := range [][]string{
+		{"--server", "--king", "-1"},
+		{"--server", "--king", "1", "--tournament", "single"},
+		{"--server", "--king", "1", "--four-way"},
+	} {
+		if _, err := ParseArgs(args); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestParseArgs_Court(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.CourtWidth != 0 || cfg.CourtHeight != 0 {
+		t.Errorf("expected no fixed court by default, got %dx%d", cfg.CourtWidth, cfg.CourtHeight)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--court", "120x30"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.CourtWidth != 120 || cfg.CourtHeight != 30 {
+		t.Errorf("expected a 120x30 court, got %dx%d", cfg.CourtWidth, cfg.CourtHeight)
+	}
+
+	for _, court := range []string{"wide", "80", "80x", "x24", "10x24", "80x5", "1000x24"} {
+		if _, err := ParseArgs([]string{"--server", "--court", court}); err == nil {
+			t.Errorf("expected error for court %q", court)
+		}
+	}
+}
+
+func TestParseArgs_Resolution(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--join", "localhost:5555"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Resolution != "cell" {
+		t.Errorf("expected cell resolution by default, got %q", cfg.Resolution)
+	}
+
+	for _, res := range []string{"half", "braille"} {
+		cfg, err = ParseArgs([]string{"--join", "localhost:5555", "--resolution", res})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Resolution != res {
+			t.Errorf("expected resolution %q, got %q", res, cfg.Resolution)
+		}
+	}
+
+	if _, err := ParseArgs([]string{"--join", "localhost:5555", "--resolution", "pixel"}); err == nil {
+		t.Error("expected error for unknown resolution")
+	}
+}
+
+func TestParseArgs_Effects(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--solo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Effects {
+		t.Error("expected effects on by default")
+	}
+
+	cfg, err = ParseArgs([]string{"--solo", "--no-effects"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Effects {
+		t.Error("expected --no-effects to turn effects off")
+	}
+}
+
+func TestParseArgs_Theme(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--solo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Theme != "" {
+		t.Errorf("expected no theme by default, got %q", cfg.Theme)
+	}
+
+	cfg, err = ParseArgs([]string{"--solo", "--theme", "colorblind"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Theme != "colorblind" {
+		t.Errorf("expected theme colorblind, got %q", cfg.Theme)
+	}
+}
+
+func TestParseArgs_ASCII(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--solo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ASCII {
+		t.Error("expected Unicode glyphs by default")
+	}
+
+	cfg, err = ParseArgs([]string{"--solo", "--ascii"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.ASCII {
+		t.Error("expected --ascii to turn ASCII glyphs on")
+	}
+}
+
+func TestParseArgs_Rules(t *testing.T) {
+	cfg, err := ParseArgs([]string{"--server"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Rules != "" {
+		t.Errorf("expected no rules by default, got %q", cfg.Rules)
+	}
+
+	cfg, err = ParseArgs([]string{"--server", "--rules", "fast"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Rules != "fast" {
+		t.Errorf("expected rules fast, got %q", cfg.Rules)
+	}
+}
@@ -6,33 +6,84 @@ import (
 )
 
 const (
-	InitialBallSpeed = 0.28 // Comfortable start speed
-	MaxBounceAngle   = math.Pi / 3 // 60 degrees max
+	InitialBallSpeed   = 0.28        // Default start speed, see Rules
+	MaxBounceAngle     = math.Pi / 3 // Default max bounce angle, see Rules
+	SpinPerPaddleSpeed = 0.01        // Spin added per cell/tick of paddle movement
+	MaxSpin            = 0.015       // Radians the ball turns per tick at most
+	SpinDecay          = 0.98        // Spin kept each tick
+	MinSpin            = 0.0005      // Spin below this stops
+	WallSpinKick       = 6.0         // How much spin turns the ball on a wall bounce
 )
 
 type Ball struct {
 	X, Y   float64
 	VX, VY float64
+	Spin   float64 // Radians the velocity turns per tick, positive turns toward +Y when moving +X
+
+	LastTouch int // Player who last hit the ball, 0 since the serve
 }
 
 func NewBall(x, y float64) *Ball {
 	return &Ball{X: x, Y: y}
 }
 
-// Move advances the ball by its velocity
+// Move advances the ball by its velocity, curving it if it spins
 func (b *Ball) Move() {
+	if b.Spin != 0 {
+		b.rotate(b.Spin)
+		b.Spin *= SpinDecay
+		if math.Abs(b.Spin) < MinSpin {
+			b.Spin = 0
+		}
+	}
 	b.X += b.VX
 	b.Y += b.VY
 }
 
-// BounceVertical reverses vertical direction (wall bounce)
+// BounceVertical reverses vertical direction (wall bounce). A spinning ball
+// grips the wall and kicks off at a different angle, losing half its spin.
 func (b *Ball) BounceVertical() {
 	b.VY = -b.VY
+	if b.Spin != 0 {
+		b.rotate(-b.Spin * WallSpinKick)
+		b.Spin = -b.Spin / 2 // The bounce mirrors the curve
+	}
+}
+
+// SpinFrom sets the spin from a paddle moving by (moveX, moveY) at contact.
+// The ball curves the way the paddle was moving.
+func (b *Ball) SpinFrom(moveX, moveY float64) {
+	speed := b.Speed()
+	if speed == 0 {
+		return
+	}
+	spin := SpinPerPaddleSpeed * (b.VX*moveY - b.VY*moveX) / speed
+	b.Spin = math.Max(-MaxSpin, math.Min(MaxSpin, spin))
+}
+
+// LimitAngle keeps the ball within maxAngle of horizontal, stopping any
+// spin that would curve it steeper
+func (b *Ball) LimitAngle(maxAngle float64) {
+	speed := b.Speed()
+	if speed == 0 || math.Abs(math.Atan2(math.Abs(b.VY), math.Abs(b.VX))) <= maxAngle {
+		return
+	}
+	vx := speed * math.Cos(maxAngle)
+	vy := speed * math.Sin(maxAngle)
+	b.VX = math.Copysign(vx, b.VX)
+	b.VY = math.Copysign(vy, b.VY)
+	b.Spin = 0
+}
+
+// rotate turns the velocity by angle radians, keeping the speed
+func (b *Ball) rotate(angle float64) {
+	sin, cos := math.Sincos(angle)
+	b.VX, b.VY = b.VX*cos-b.VY*sin, b.VX*sin+b.VY*cos
 }
 
 // BounceOffPaddle bounces ball off paddle, angle based on hit position
-// paddleY is center Y, paddleHeight is total height
-func (b *Ball) BounceOffPaddle(paddleY float64, paddleHeight int) {
+// paddleY is center Y, paddleHeight is total height, maxAngle is in radians
+func (b *Ball) BounceOffPaddle(paddleY float64, paddleHeight int, maxAngle float64) {
 	// Calculate where on paddle ball hit (-1 to 1, 0 = center)
 	relativeHit := (b.Y - paddleY) / (float64(paddleHeight) / 2)
 	if relativeHit < -1 {
@@ -43,7 +94,7 @@ func (b *Ball) BounceOffPaddle(paddleY float64, paddleHeight int) {
 	}
 
 	// Bounce angle based on hit position
-	bounceAngle := relativeHit * MaxBounceAngle
+	bounceAngle := relativeHit * maxAngle
 	speed := math.Sqrt(b.VX*b.VX + b.VY*b.VY)
 
 	// Reverse horizontal direction
@@ -55,6 +106,14 @@ func (b *Ball) BounceOffPaddle(paddleY float64, paddleHeight int) {
 	b.VY = speed * math.Sin(bounceAngle)
 }
 
+// BounceOffRowPaddle bounces ball off a horizontal paddle, the same way
+// BounceOffPaddle does for vertical ones
+func (b *Ball) BounceOffRowPaddle(paddleX float64, paddleWidth int, maxAngle float64) {
+	b.X, b.Y, b.VX, b.VY = b.Y, b.X, b.VY, b.VX
+	b.BounceOffPaddle(paddleX, paddleWidth, maxAngle)
+	b.X, b.Y, b.VX, b.VY = b.Y, b.X, b.VY, b.VX
+}
+
 // SpeedUp multiplies ball speed by factor
 func (b *Ball) SpeedUp(factor float64) {
 	b.VX *= factor
@@ -67,16 +126,28 @@ func (b *Ball) Speed() float64 {
 }
 
 // Reset places ball at center and launches in specified direction
-func (b *Ball) Reset(centerX, centerY float64, launchRight bool) {
-	b.X = centerX
-	b.Y = centerY
-
-	angle := (rand.Float64() - 0.5) * math.Pi / 3
-	speed := InitialBallSpeed
+func (b *Ball) Reset(centerX, centerY float64, launchRight bool, speed float64) {
 	if launchRight {
-		b.VX = speed * math.Cos(angle)
+		b.Launch(centerX, centerY, 1, 0, speed)
 	} else {
-		b.VX = -speed * math.Cos(angle)
+		b.Launch(centerX, centerY, -1, 0, speed)
 	}
-	b.VY = speed * math.Sin(angle)
+}
+
+// Launch places ball at center and launches it along (dirX, dirY), which
+// must be one of the four axis directions, give or take 30 degrees
+func (b *Ball) Launch(centerX, centerY, dirX, dirY, speed float64) {
+	b.LaunchAt(centerX, centerY, dirX, dirY, (rand.Float64()-0.5)*math.Pi/3, speed)
+}
+
+// LaunchAt places the ball and sends it along (dirX, dirY), turned by angle
+func (b *Ball) LaunchAt(centerX, centerY, dirX, dirY, angle, speed float64) {
+	b.X = centerX
+	b.Y = centerY
+	b.Spin = 0
+	b.LastTouch = 0
+
+	along, across := speed*math.Cos(angle), speed*math.Sin(angle)
+	b.VX = dirX*along - dirY*across
+	b.VY = dirY*along + dirX*across
 }
@@ -44,7 +44,7 @@ func TestBall_BounceOffPaddle(t *testing.T) {
 	paddleY := 10.0
 	paddleHeight := 6
 
-	ball.BounceOffPaddle(paddleY, paddleHeight)
+	ball.BounceOffPaddle(paddleY, paddleHeight, MaxBounceAngle)
 
 	// Ball was moving right, should now move left
 	if ball.VX >= 0 {
@@ -67,7 +67,7 @@ func TestBall_BounceOffPaddle_Edge(t *testing.T) {
 	paddleY := 7.0    // Paddle center is at 7, so ball at 10 is hitting upper edge
 	paddleHeight := 6 // Paddle extends from 4 to 10
 
-	ball.BounceOffPaddle(paddleY, paddleHeight)
+	ball.BounceOffPaddle(paddleY, paddleHeight, MaxBounceAngle)
 
 	// Ball was moving right, should now move left
 	if ball.VX >= 0 {
@@ -113,7 +113,7 @@ func TestBall_Reset(t *testing.T) {
 	centerY := 12.0
 
 	// Test reset launching right
-	ball.Reset(centerX, centerY, true)
+	ball.Reset(centerX, centerY, true, InitialBallSpeed)
 
 	if ball.X != centerX {
 		t.Errorf("expected X=%f, got %f", centerX, ball.X)
@@ -131,7 +131,7 @@ func TestBall_Reset(t *testing.T) {
 	}
 
 	// Test reset launching left
-	ball.Reset(centerX, centerY, false)
+	ball.Reset(centerX, centerY, false, InitialBallSpeed)
 
 	if ball.VX >= 0 {
 		t.Errorf("expected VX < 0 when launching left, got %f", ball.VX)
@@ -150,3 +150,95 @@ func TestBall_Speed(t *testing.T) {
 		t.Errorf("expected speed=5.0, got %f", speed)
 	}
 }
+
+func TestBall_SpinFrom(t *testing.T) {
+	// Ball leaving a left paddle that was moving down curves down
+	ball := NewBall(5.0, 10.0)
+	ball.VX = 0.5
+	ball.SpinFrom(0, 1.0)
+	if ball.Spin <= 0 {
+		t.Fatalf("expected positive spin, got %f", ball.Spin)
+	}
+
+	// Same paddle motion on a ball going left curves it down too
+	ball.VX = -0.5
+	ball.SpinFrom(0, 1.0)
+	if ball.Spin >= 0 {
+		t.Errorf("expected negative spin, got %f", ball.Spin)
+	}
+
+	// A fast paddle can't spin the ball past the limit
+	ball.SpinFrom(0, 100)
+	if math.Abs(ball.Spin) > MaxSpin {
+		t.Errorf("expected spin capped at %f, got %f", MaxSpin, ball.Spin)
+	}
+
+	// A still paddle takes the spin away
+	ball.SpinFrom(0, 0)
+	if ball.Spin != 0 {
+		t.Errorf("expected no spin, got %f", ball.Spin)
+	}
+}
+
+func TestBall_SpinCurves(t *testing.T) {
+	ball := NewBall(10.0, 10.0)
+	ball.VX = 0.5
+	ball.Spin = MaxSpin
+
+	for i := 0; i < 10; i++ {
+		ball.Move()
+	}
+
+	if ball.VY <= 0 {
+		t.Errorf("expected ball to curve down, got VY=%f", ball.VY)
+	}
+	if math.Abs(ball.Speed()-0.5) > 1e-9 {
+		t.Errorf("expected spin to keep the speed at 0.5, got %f", ball.Speed())
+	}
+	if ball.Spin >= MaxSpin {
+		t.Errorf("expected spin to decay, got %f", ball.Spin)
+	}
+}
+
+func TestBall_BounceVertical_WithSpin(t *testing.T) {
+	plain := NewBall(10.0, 0.0)
+	plain.VX = 0.5
+	plain.VY = -0.3
+	plain.BounceVertical()
+
+	spinning := NewBall(10.0, 0.0)
+	spinning.VX = 0.5
+	spinning.VY = -0.3
+	spinning.Spin = MaxSpin
+	spinning.BounceVertical()
+
+	if spinning.VY <= 0 {
+		t.Fatalf("expected ball to bounce down, got VY=%f", spinning.VY)
+	}
+	if spinning.VX == plain.VX {
+		t.Error("expected spin to change the bounce angle")
+	}
+	if spinning.Spin != -MaxSpin/2 {
+		t.Errorf("expected spin %f after the bounce, got %f", -MaxSpin/2, spinning.Spin)
+	}
+}
+
+func TestBall_LimitAngle(t *testing.T) {
+	ball := NewBall(10.0, 10.0)
+	ball.VX = 0.1
+	ball.VY = -0.5
+	ball.Spin = MaxSpin
+
+	ball.LimitAngle(MaxBounceAngle)
+
+	angle := math.Atan2(math.Abs(ball.VY), math.Abs(ball.VX))
+	if angle > MaxBounceAngle+1e-9 {
+		t.Errorf("expected angle at most %f, got %f", MaxBounceAngle, angle)
+	}
+	if ball.VX <= 0 || ball.VY >= 0 {
+		t.Errorf("expected direction to be kept, got VX=%f VY=%f", ball.VX, ball.VY)
+	}
+	if ball.Spin != 0 {
+		t.Errorf("expected spin to stop, got %f", ball.Spin)
+	}
+}
@@ -0,0 +1,234 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// Difficulty controls how well a bot plays
+type Difficulty int
+
+const (
+	DifficultyEasy Difficulty = iota
+	DifficultyNormal
+	DifficultyHard
+)
+
+// BotServeDelay is how long a bot waits before serving
+const BotServeDelay = TickRate * 3 / 2
+
+// botProfile holds the tuning for one difficulty level
+type botProfile struct {
+	reactionTicks int     // Ticks between looks at the ball
+	aimError      float64 // Max error in the predicted arrival Y (cells)
+	inputInterval int     // Ticks between inputs, like a human key repeat
+}
+
+var botProfiles = map[Difficulty]botProfile{
+	DifficultyEasy:   {reactionTicks: 24, aimError: 3.0, inputInterval: 6},
+	DifficultyNormal: {reactionTicks: 12, aimError: 1.5, inputInterval: 4},
+	DifficultyHard:   {reactionTicks: 4, aimError: 0.5, inputInterval: 2},
+}
+
+// ParseDifficulty converts a difficulty name to a Difficulty
+func ParseDifficulty(name string) (Difficulty, error) {
+	switch name {
+	case "easy":
+		return DifficultyEasy, nil
+	case "normal":
+		return DifficultyNormal, nil
+	case "hard":
+		return DifficultyHard, nil
+	}
+	return DifficultyNormal, fmt.Errorf("unknown difficulty %q", name)
+}
+
+// String returns the difficulty name
+func (d Difficulty) String() string {
+	switch d {
+	case DifficultyEasy:
+		return "easy"
+	case DifficultyHard:
+		return "hard"
+	}
+	return "normal"
+}
+
+// Bot is a computer-controlled player that drives a paddle
+type Bot struct {
+	ID         int
+	Name       string
+	Color      int
+	Difficulty Difficulty
+
+	targetY      float64
+	hasTarget    bool
+	reactionLeft int
+	inputLeft    int
+	serveWait    int
+	serveAim     float64 // Offset from the middle to serve from
+}
+
+// NewBot creates a bot with the given player ID and difficulty
+func NewBot(id int, name string, color int, difficulty Difficulty) *Bot {
+	return &Bot{
+		ID:         id,
+		Name:       name,
+		Color:      color,
+		Difficulty: difficulty,
+	}
+}
+
+// Decide returns the direction the bot wants to move this tick
+func (b *Bot) Decide(gs *GameState) protocol.Direction {
+	paddle := gs.GetPaddle(b.ID)
+	if paddle == nil || gs.Paused {
+		b.hasTarget = false
+		return protocol.DirNone
+	}
+
+	profile := botProfiles[b.Difficulty]
+
+	// Only look at the ball every few ticks to simulate reaction time
+	b.reactionLeft--
+	if b.reactionLeft <= 0 || !b.hasTarget {
+		b.reactionLeft = profile.reactionTicks
+		b.targetY = b.chooseTarget(gs, paddle, profile)
+		b.hasTarget = true
+	}
+
+	// Rate limit inputs like a held key
+	b.inputLeft--
+	if b.inputLeft > 0 {
+		return protocol.DirNone
+	}
+	b.inputLeft = profile.inputInterval
+
+	diff := b.targetY - paddle.TargetY
+	if math.Abs(diff) < paddle.Step/2 {
+		return protocol.DirNone
+	}
+
+	// Reversed controls fool easy bots, the others compensate
+	down := diff > 0
+	if paddle.Reversed && b.Difficulty != DifficultyEasy {
+		down = !down
+	}
+
+	// Horizontal paddles move right where vertical ones move down
+	switch {
+	case paddle.Horizontal && down:
+		return protocol.DirRight
+	case paddle.Horizontal:
+		return protocol.DirLeft
+	case down:
+		return protocol.DirDown
+	}
+	return protocol.DirUp
+}
+
+// chooseTarget picks where the paddle should go based on the ball
+func (b *Bot) chooseTarget(gs *GameState, paddle *Paddle, profile botProfile) float64 {
+	centerY := float64(paddle.CourtHeight) / 2
+	if gs.WaitingForServe {
+		if paddle.Team == gs.ServingTeam {
+			return centerY + b.serveAim
+		}
+		return centerY
+	}
+
+	// Defend against the ball that will arrive first
+	target, soonest := centerY, math.Inf(1)
+	for _, ball := range gs.balls() {
+		if !ballHeadingTo(ball, paddle.Team) {
+			continue
+		}
+		y, ticks, ok := gs.predictArrival(ball, paddle.Column)
+		if paddle.Horizontal {
+			y, ticks, ok = gs.predictRowArrival(ball, paddle.Column)
+		}
+		if ok && ticks < soonest {
+			target, soonest = y, ticks
+		}
+	}
+
+	if math.IsInf(soonest, 1) {
+		// Drift back to the middle while the balls are going away
+		return centerY
+	}
+
+	return target + (rand.Float64()*2-1)*profile.aimError
+}
+
+// WantsServe returns true when the bot should serve for its team
+func (b *Bot) WantsServe(gs *GameState) bool {
+	paddle := gs.GetPaddle(b.ID)
+	if paddle == nil || !gs.WaitingForServe || paddle.Team != gs.ServingTeam ||
+		(gs.ServingPlayer != 0 && gs.ServingPlayer != b.ID) {
+		b.serveWait = 0
+		return false
+	}
+
+	// Pick a spot to serve from, so serves aren't all alike
+	if b.serveWait == 0 {
+		b.serveAim = (rand.Float64()*2 - 1) * float64(paddle.CourtHeight) / 4
+	}
+	b.serveWait++
+	return b.serveWait >= BotServeDelay
+}
+
+// ballHeadingTo returns true if the ball is moving toward the team's goal
+func ballHeadingTo(ball *Ball, team protocol.Team) bool {
+	switch team {
+	case protocol.TeamLeft:
+		return ball.VX < 0
+	case protocol.TeamTop:
+		return ball.VY < 0
+	case protocol.TeamBottom:
+		return ball.VY > 0
+	}
+	return ball.VX > 0
+}
+
+// PredictBallY predicts the Y position where the ball will cross the given
+// column, following wall bounces. Returns false if the ball will not reach it.
+func (gs *GameState) PredictBallY(column int) (float64, bool) {
+	y, _, ok := gs.predictArrival(gs.Ball, column)
+	return y, ok
+}
+
+// predictArrival returns where and in how many ticks a ball crosses a column
+func (gs *GameState) predictArrival(ball *Ball, column int) (float64, float64, bool) {
+	return predictCrossing(ball.X, ball.VX, ball.Y, ball.VY, float64(column), float64(gs.Height))
+}
+
+// predictRowArrival returns where and in how many ticks a ball crosses a row
+func (gs *GameState) predictRowArrival(ball *Ball, row int) (float64, float64, bool) {
+	return predictCrossing(ball.Y, ball.VY, ball.X, ball.VX, float64(row), float64(gs.Width))
+}
+
+// predictCrossing follows a ball moving along one axis until it reaches
+// line, bouncing between 0 and extent on the other axis
+func predictCrossing(pos, v, across, vAcross, line, extent float64) (float64, float64, bool) {
+	if v == 0 {
+		return 0, 0, false
+	}
+
+	ticks := (line - pos) / v
+	if ticks < 0 {
+		return 0, 0, false
+	}
+
+	// Unfold the wall bounces: the path repeats every two court heights
+	y := math.Mod(across+vAcross*ticks, 2*extent)
+	if y < 0 {
+		y += 2 * extent
+	}
+	if y > extent {
+		y = 2*extent - y
+	}
+	return y, ticks, true
+}
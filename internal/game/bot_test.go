@@ -0,0 +1,148 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// botTestID is the player ID used for bots in tests
+const botTestID = 1001
+
+func TestParseDifficulty(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Difficulty
+		wantErr bool
+	}{
+		{"easy", DifficultyEasy, false},
+		{"normal", DifficultyNormal, false},
+		{"hard", DifficultyHard, false},
+		{"impossible", DifficultyNormal, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDifficulty(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDifficulty(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDifficulty(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.name {
+				t.Errorf("expected String()=%q, got %q", tt.name, got.String())
+			}
+		})
+	}
+}
+
+func TestGameState_PredictBallY(t *testing.T) {
+	t.Run("straight line", func(t *testing.T) {
+		gs := NewGameState(80, 24, 10)
+		gs.Ball = NewBall(40, 12)
+		gs.Ball.VX = -1
+		gs.Ball.VY = 0.1
+
+		y, ok := gs.PredictBallY(10)
+		if !ok {
+			t.Fatal("expected ball to reach column 10")
+		}
+		if math.Abs(y-15) > 0.001 {
+			t.Errorf("expected Y=15, got %f", y)
+		}
+	})
+
+	t.Run("bounces off bottom wall", func(t *testing.T) {
+		gs := NewGameState(80, 24, 10)
+		gs.Ball = NewBall(40, 20)
+		gs.Ball.VX = 1
+		gs.Ball.VY = 0.5
+
+		// Unbounded path would reach Y=30, which reflects to 18
+		y, ok := gs.PredictBallY(60)
+		if !ok {
+			t.Fatal("expected ball to reach column 60")
+		}
+		if math.Abs(y-18) > 0.001 {
+			t.Errorf("expected Y=18, got %f", y)
+		}
+	})
+
+	t.Run("moving away", func(t *testing.T) {
+		gs := NewGameState(80, 24, 10)
+		gs.Ball = NewBall(40, 12)
+		gs.Ball.VX = 1
+
+		if _, ok := gs.PredictBallY(10); ok {
+			t.Error("expected no prediction for a ball moving away")
+		}
+	})
+}
+
+func TestBot_DecideMovesTowardBall(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.AddPlayer(1, "P1")
+	gs.AddPlayer(botTestID, "Bot")
+	gs.AssignTeams()
+
+	bot := NewBot(botTestID, "Bot", 7, DifficultyHard)
+	paddle := gs.GetPaddle(botTestID)
+
+	// Send the ball toward the bottom of the bot's side
+	gs.Ball.X = float64(gs.Width) / 2
+	gs.Ball.Y = 20
+	gs.Ball.VY = 0
+	if paddle.Team == protocol.TeamLeft {
+		gs.Ball.VX = -0.5
+	} else {
+		gs.Ball.VX = 0.5
+	}
+
+	for i := 0; i < TickRate; i++ {
+		if dir := bot.Decide(gs); dir != protocol.DirNone {
+			gs.ProcessInput(botTestID, dir)
+		}
+		paddle.Update()
+	}
+
+	if paddle.TargetY <= float64(gs.Height)/2 {
+		t.Errorf("expected bot to move down toward the ball, TargetY=%f", paddle.TargetY)
+	}
+}
+
+func TestBot_WantsServe(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.AddPlayer(botTestID, "Bot")
+	gs.AddPlayer(2, "P2")
+	gs.AssignTeams()
+
+	bot := NewBot(botTestID, "Bot", 7, DifficultyNormal)
+	paddle := gs.GetPaddle(botTestID)
+
+	gs.WaitingForServe = true
+	gs.ServingTeam = paddle.Team
+
+	for i := 0; i < BotServeDelay-1; i++ {
+		if bot.WantsServe(gs) {
+			t.Fatalf("bot served too early at tick %d", i)
+		}
+	}
+	if !bot.WantsServe(gs) {
+		t.Error("expected bot to serve after the delay")
+	}
+
+	// Never serve for the other team
+	other := NewBot(botTestID, "Bot", 7, DifficultyNormal)
+	if paddle.Team == protocol.TeamLeft {
+		gs.ServingTeam = protocol.TeamRight
+	} else {
+		gs.ServingTeam = protocol.TeamLeft
+	}
+	for i := 0; i < BotServeDelay*2; i++ {
+		if other.WantsServe(gs) {
+			t.Fatal("bot should not serve for the other team")
+		}
+	}
+}
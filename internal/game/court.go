@@ -0,0 +1,278 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// BumperBoost is the ball speed multiplier when it hits a bumper
+const BumperBoost = 1.1
+
+// Map is a court layout loaded from a JSON map file. Positions and sizes are
+// fractions of the court (0 to 1), so the same map fits any court size.
+type Map struct {
+	Name      string      `json:"name"`
+	Blocks    []MapBlock  `json:"blocks"`
+	Bumpers   []MapBumper `json:"bumpers"`
+	GoalSize  float64     `json:"goal_size"`  // Open part of each goal line, 0 = whole edge
+	CenterGap float64     `json:"center_gap"` // Opening in a wall along the center line, 0 = no wall
+}
+
+// MapBlock is a rectangular obstacle, from its top-left corner
+type MapBlock struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w"`
+	H float64 `json:"h"`
+}
+
+// MapBumper is a round obstacle that kicks the ball away, R is relative to the court height
+type MapBumper struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	R float64 `json:"r"`
+}
+
+// LoadMap reads and validates a map file
+func LoadMap(path string) (*Map, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read map: %w", err)
+	}
+	return ParseMap(data)
+}
+
+// ParseMap decodes and validates a JSON map
+func ParseMap(data []byte) (*Map, error) {
+	var m Map
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse map: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate checks that every shape fits on the court
+func (m *Map) Validate() error {
+	if m.Name == "" {
+		return errors.New("map needs a name")
+	}
+	for i, b := range m.Blocks {
+		if b.W <= 0 || b.H <= 0 {
+			return fmt.Errorf("block %d must have a positive size", i+1)
+		}
+		if b.X < 0 || b.Y < 0 || b.X+b.W > 1 || b.Y+b.H > 1 {
+			return fmt.Errorf("block %d must fit on the court (0 to 1)", i+1)
+		}
+	}
+	for i, b := range m.Bumpers {
+		if b.R <= 0 || b.R > 0.5 {
+			return fmt.Errorf("bumper %d radius must be between 0 and 0.5", i+1)
+		}
+		if b.X < 0 || b.X > 1 || b.Y < 0 || b.Y > 1 {
+			return fmt.Errorf("bumper %d must be on the court (0 to 1)", i+1)
+		}
+	}
+	if m.GoalSize < 0 || m.GoalSize > 1 {
+		return fmt.Errorf("goal_size must be between 0 and 1, got %g", m.GoalSize)
+	}
+	if m.CenterGap < 0 || m.CenterGap >= 1 {
+		return fmt.Errorf("center_gap must be between 0 and 1, got %g", m.CenterGap)
+	}
+	return nil
+}
+
+// Block is a rectangular obstacle in court cells
+type Block struct {
+	X, Y, W, H float64
+}
+
+// Bumper is a round obstacle in court cells
+type Bumper struct {
+	X, Y, R float64
+}
+
+// Court is a map laid out on a court of a given size
+type Court struct {
+	Blocks     []Block
+	Bumpers    []Bumper
+	GoalTop    float64 // Goal mouth on both edges, the rest is wall
+	GoalBottom float64
+}
+
+// Layout scales the map to a court of the given size
+func (m *Map) Layout(width, height int) *Court {
+	w, h := float64(width), float64(height)
+
+	court := &Court{GoalTop: 0, GoalBottom: h}
+	for _, b := range m.Blocks {
+		court.Blocks = append(court.Blocks, Block{X: b.X * w, Y: b.Y * h, W: b.W * w, H: b.H * h})
+	}
+	for _, b := range m.Bumpers {
+		court.Bumpers = append(court.Bumpers, Bumper{X: b.X * w, Y: b.Y * h, R: b.R * h})
+	}
+
+	if m.GoalSize > 0 {
+		court.GoalTop = h * (1 - m.GoalSize) / 2
+		court.GoalBottom = h * (1 + m.GoalSize) / 2
+	}
+
+	// A divided center is a one-cell wall with an opening in the middle
+	if m.CenterGap > 0 {
+		wallH := h * (1 - m.CenterGap) / 2
+		centerX := w/2 - 0.5
+		court.Blocks = append(court.Blocks,
+			Block{X: centerX, Y: 0, W: 1, H: wallH},
+			Block{X: centerX, Y: h - wallH, W: 1, H: wallH},
+		)
+	}
+	return court
+}
+
+// SetMap lays out a map on the court, or clears it when nil
+func (gs *GameState) SetMap(m *Map) {
+	if m == nil {
+		gs.Court = nil
+		return
+	}
+	gs.Court = m.Layout(gs.Width, gs.Height)
+}
+
+// collideCourt bounces a ball off the map obstacles and closed goal lines
+func (gs *GameState) collideCourt(ball *Ball) {
+	if gs.Court == nil {
+		return
+	}
+
+	for _, block := range gs.Court.Blocks {
+		if bounceOffBlock(ball, block) {
+			break
+		}
+	}
+
+	for _, bumper := range gs.Court.Bumpers {
+		if gs.bounceOffBumper(ball, bumper) {
+			break
+		}
+	}
+
+	// Outside the goal mouth the edges are walls
+	outsideGoal := ball.Y < gs.Court.GoalTop || ball.Y > gs.Court.GoalBottom
+	if outsideGoal && ball.X < 0 && ball.VX < 0 {
+		ball.X = -ball.X
+		ball.VX = -ball.VX
+	}
+	if outsideGoal && ball.X > float64(gs.Width) && ball.VX > 0 {
+		ball.X = 2*float64(gs.Width) - ball.X
+		ball.VX = -ball.VX
+	}
+}
+
+// bounceOffBlock reflects the ball if its path this tick entered the block
+func bounceOffBlock(ball *Ball, block Block) bool {
+	// Clip the path travelled this tick against the block (slab method)
+	startX, startY := ball.X-ball.VX, ball.Y-ball.VY
+	enter, exit := 0.0, 1.0
+	enterAxisX := false
+
+	axes := []struct {
+		start, v, min, max float64
+		isX                bool
+	}{
+		{startX, ball.VX, block.X, block.X + block.W, true},
+		{startY, ball.VY, block.Y, block.Y + block.H, false},
+	}
+	for _, axis := range axes {
+		if axis.v == 0 {
+			if axis.start < axis.min || axis.start > axis.max {
+				return false
+			}
+			continue
+		}
+		t1 := (axis.min - axis.start) / axis.v
+		t2 := (axis.max - axis.start) / axis.v
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > enter {
+			enter = t1
+			enterAxisX = axis.isX
+		}
+		exit = math.Min(exit, t2)
+		if enter > exit {
+			return false
+		}
+	}
+
+	// Already inside at the start of the tick: let it leave
+	if enter == 0 {
+		return false
+	}
+
+	// Stop at the surface and bounce off the face it hit
+	ball.X = startX + ball.VX*enter
+	ball.Y = startY + ball.VY*enter
+	if enterAxisX {
+		ball.VX = -ball.VX
+	} else {
+		ball.VY = -ball.VY
+	}
+	return true
+}
+
+// bounceOffBumper reflects the ball off a bumper and speeds it up
+func (gs *GameState) bounceOffBumper(ball *Ball, bumper Bumper) bool {
+	dx, dy := ball.X-bumper.X, ball.Y-bumper.Y
+	dist := math.Hypot(dx, dy)
+	if dist >= bumper.R || dist == 0 {
+		return false
+	}
+
+	// Only bounce when moving toward the center
+	nx, ny := dx/dist, dy/dist
+	dot := ball.VX*nx + ball.VY*ny
+	if dot >= 0 {
+		return false
+	}
+
+	ball.VX -= 2 * dot * nx
+	ball.VY -= 2 * dot * ny
+	ball.X = bumper.X + nx*bumper.R
+	ball.Y = bumper.Y + ny*bumper.R
+
+	// Kick the ball, but never past the fastest paddle speed cap
+	ball.SpeedUp(BumperBoost)
+	playersPerSide := max(1, gs.countPlayersOnSide(protocol.TeamLeft), gs.countPlayersOnSide(protocol.TeamRight))
+	speedCap := gs.GetSpeedCap(playersPerSide)
+	if ball.Speed() > speedCap {
+		scale := speedCap / ball.Speed()
+		ball.VX *= scale
+		ball.VY *= scale
+	}
+	return true
+}
+
+// CourtLayout converts the court to its protocol representation
+func (gs *GameState) CourtLayout() protocol.CourtLayout {
+	layout := protocol.CourtLayout{GoalTop: 0, GoalBottom: float64(gs.Height)}
+	if gs.Court == nil {
+		return layout
+	}
+
+	for _, b := range gs.Court.Blocks {
+		layout.Blocks = append(layout.Blocks, protocol.BlockState{X: b.X, Y: b.Y, W: b.W, H: b.H})
+	}
+	for _, b := range gs.Court.Bumpers {
+		layout.Bumpers = append(layout.Bumpers, protocol.BumperState{X: b.X, Y: b.Y, R: b.R})
+	}
+	layout.GoalTop = gs.Court.GoalTop
+	layout.GoalBottom = gs.Court.GoalBottom
+	return layout
+}
@@ -0,0 +1,197 @@
+package game
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseMap(t *testing.T) {
+	data := []byte(`{
+		"name": "Pillars",
+		"blocks": [{"x": 0.4, "y": 0.1, "w": 0.05, "h": 0.2}],
+		"bumpers": [{"x": 0.5, "y": 0.5, "r": 0.1}],
+		"goal_size": 0.5,
+		"center_gap": 0.4
+	}`)
+
+	m, err := ParseMap(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Name != "Pillars" || len(m.Blocks) != 1 || len(m.Bumpers) != 1 {
+		t.Errorf("unexpected map: %+v", m)
+	}
+}
+
+func TestParseMap_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"bad json", `{"name": `},
+		{"no name", `{}`},
+		{"block off court", `{"name": "x", "blocks": [{"x": 0.9, "y": 0, "w": 0.2, "h": 0.1}]}`},
+		{"empty block", `{"name": "x", "blocks": [{"x": 0.5, "y": 0.5, "w": 0, "h": 0.1}]}`},
+		{"huge bumper", `{"name": "x", "bumpers": [{"x": 0.5, "y": 0.5, "r": 0.8}]}`},
+		{"goal size", `{"name": "x", "goal_size": 1.5}`},
+		{"center gap", `{"name": "x", "center_gap": 1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseMap([]byte(tt.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "arena.json")
+	if err := os.WriteFile(path, []byte(`{"name": "Arena", "goal_size": 0.5}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadMap(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Name != "Arena" {
+		t.Errorf("expected name Arena, got %q", m.Name)
+	}
+
+	if _, err := LoadMap(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestMap_Layout(t *testing.T) {
+	m := &Map{
+		Name:      "Test",
+		Blocks:    []MapBlock{{X: 0.25, Y: 0.5, W: 0.1, H: 0.25}},
+		GoalSize:  0.5,
+		CenterGap: 0.5,
+	}
+
+	court := m.Layout(80, 20)
+
+	block := court.Blocks[0]
+	if block.X != 20 || block.Y != 10 || block.W != 8 || block.H != 5 {
+		t.Errorf("unexpected block layout: %+v", block)
+	}
+	if court.GoalTop != 5 || court.GoalBottom != 15 {
+		t.Errorf("expected goal mouth 5-15, got %f-%f", court.GoalTop, court.GoalBottom)
+	}
+
+	// The divided center adds a wall above and below the opening
+	if len(court.Blocks) != 3 {
+		t.Fatalf("expected 3 blocks with the center wall, got %d", len(court.Blocks))
+	}
+	if top := court.Blocks[1]; top.Y != 0 || top.H != 5 {
+		t.Errorf("unexpected top center wall: %+v", top)
+	}
+}
+
+func TestGameState_BlockBounce(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.Court = &Court{
+		Blocks:     []Block{{X: 40, Y: 10, W: 2, H: 4}},
+		GoalBottom: 24,
+	}
+
+	// Ball moving right into the left face of the block
+	gs.Ball = NewBall(39.5, 12)
+	gs.Ball.VX = 1
+	gs.moveBall(gs.Ball)
+
+	if gs.Ball.VX >= 0 {
+		t.Errorf("expected ball to bounce back, VX=%f", gs.Ball.VX)
+	}
+	if gs.Ball.X > 40 {
+		t.Errorf("expected ball to stay out of the block, X=%f", gs.Ball.X)
+	}
+
+	// Ball moving down onto the top face
+	gs.Ball = NewBall(41, 9.5)
+	gs.Ball.VY = 1
+	gs.moveBall(gs.Ball)
+
+	if gs.Ball.VY >= 0 {
+		t.Errorf("expected ball to bounce up, VY=%f", gs.Ball.VY)
+	}
+}
+
+func TestGameState_BumperBounce(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.Court = &Court{
+		Bumpers:    []Bumper{{X: 40, Y: 12, R: 2}},
+		GoalBottom: 24,
+	}
+
+	gs.Ball = NewBall(37.5, 12)
+	gs.Ball.VX = 0.8
+	speed := gs.Ball.Speed()
+	gs.moveBall(gs.Ball)
+
+	if gs.Ball.VX >= 0 {
+		t.Errorf("expected ball to bounce off the bumper, VX=%f", gs.Ball.VX)
+	}
+	if math.Hypot(gs.Ball.X-40, gs.Ball.Y-12) < 2-0.001 {
+		t.Errorf("expected ball pushed out of the bumper")
+	}
+	if gs.Ball.Speed() <= speed {
+		t.Errorf("expected the bumper to speed up the ball")
+	}
+}
+
+func TestGameState_GoalMouth(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.AddPlayer(1, "P1")
+	gs.AddPlayer(2, "P2")
+	gs.AssignTeams()
+	gs.SetMap(&Map{Name: "Narrow", GoalSize: 0.5})
+
+	// Outside the mouth the edge is a wall
+	gs.Ball.X = 0.2
+	gs.Ball.Y = 2
+	gs.Ball.VX = -0.5
+	gs.Ball.VY = 0
+	gs.moveBall(gs.Ball)
+	gs.CheckScore()
+
+	if gs.RightScore != 0 {
+		t.Errorf("expected no goal outside the goal mouth")
+	}
+	if gs.Ball.VX <= 0 {
+		t.Errorf("expected ball to bounce off the goal wall")
+	}
+
+	// Inside the mouth it scores as usual
+	gs.Ball.X = 0.2
+	gs.Ball.Y = 12
+	gs.Ball.VX = -0.5
+	gs.moveBall(gs.Ball)
+	gs.CheckScore()
+
+	if gs.RightScore != 1 {
+		t.Errorf("expected a goal inside the goal mouth, got %d", gs.RightScore)
+	}
+}
+
+func TestExampleMaps(t *testing.T) {
+	paths, err := filepath.Glob("../../maps/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("expected example maps in maps/")
+	}
+
+	for _, path := range paths {
+		if _, err := LoadMap(path); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// FourWayTeams lists the teams in four-way mode, in the order they fill up
+var FourWayTeams = []protocol.Team{
+	protocol.TeamLeft,
+	protocol.TeamRight,
+	protocol.TeamTop,
+	protocol.TeamBottom,
+}
+
+// preferredTeam returns the team a four-way preference asks for
+func preferredTeam(pref protocol.TeamPreference) (protocol.Team, bool) {
+	switch pref {
+	case protocol.PreferLeft:
+		return protocol.TeamLeft, true
+	case protocol.PreferRight:
+		return protocol.TeamRight, true
+	case protocol.PreferTop:
+		return protocol.TeamTop, true
+	case protocol.PreferBottom:
+		return protocol.TeamBottom, true
+	}
+	return protocol.TeamLeft, false
+}
+
+// ResolveFourTeams splits players into up to four teams. Players who picked
+// a side get it, the rest are shuffled into the smallest teams. With fewer
+// than four players only the first teams in FourWayTeams are used.
+func ResolveFourTeams(ids []int, prefs map[int]protocol.TeamPreference) map[protocol.Team][]int {
+	active := FourWayTeams
+	if len(ids) < len(active) {
+		active = active[:len(ids)]
+	}
+
+	teams := make(map[protocol.Team][]int)
+	var random []int
+	for _, id := range ids {
+		if team, ok := preferredTeam(prefs[id]); ok {
+			teams[team] = append(teams[team], id)
+		} else {
+			random = append(random, id)
+		}
+	}
+
+	rand.Shuffle(len(random), func(i, j int) {
+		random[i], random[j] = random[j], random[i]
+	})
+
+	for _, id := range random {
+		smallest := active[0]
+		for _, team := range active[1:] {
+			if len(teams[team]) < len(teams[smallest]) {
+				smallest = team
+			}
+		}
+		teams[smallest] = append(teams[smallest], id)
+	}
+	return teams
+}
+
+// BalanceFourByRating deals players into four teams by rating, strongest
+// first, snaking back and forth so every team gets a similar mix
+func BalanceFourByRating(ids []int, ratings map[int]float64) map[int]protocol.TeamPreference {
+	sorted := append([]int(nil), ids...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return ratings[sorted[i]] > ratings[sorted[j]]
+	})
+
+	balanced := make(map[int]protocol.TeamPreference, len(ids))
+	count := len(FourWayTeams)
+	for i, id := range sorted {
+		slot := i % count
+		if (i/count)%2 == 1 {
+			slot = count - 1 - slot
+		}
+		balanced[id] = PreferenceFor(FourWayTeams[slot])
+	}
+	return balanced
+}
+
+// assignFourTeams places players on four teams and positions paddles. Teams
+// nobody plays on are out from the start, so their edge is a wall.
+func (gs *GameState) assignFourTeams(prefs map[int]protocol.TeamPreference) {
+	ids := make([]int, len(gs.Paddles))
+	for i, p := range gs.Paddles {
+		ids[i] = p.ID
+	}
+	teams := ResolveFourTeams(ids, prefs)
+
+	var alive []protocol.Team
+	for _, team := range FourWayTeams {
+		members := make([]*Paddle, 0, len(teams[team]))
+		for _, id := range teams[team] {
+			members = append(members, gs.GetPaddle(id))
+		}
+
+		gs.Out[team] = len(members) == 0
+		if !gs.Out[team] {
+			alive = append(alive, team)
+		}
+
+		height := gs.CalculatePaddleHeight(len(members))
+		if team == protocol.TeamTop || team == protocol.TeamBottom {
+			// Cover the same share of the court width as side paddles do of its height
+			gs.assignRowPaddles(members, team, height*gs.Width/gs.Height)
+		} else {
+			gs.assignTeamPaddles(members, team, height)
+		}
+	}
+
+	if len(alive) > 0 {
+		gs.launchFrom(alive[rand.Intn(len(alive))])
+	}
+}
+
+// assignRowPaddles sets up horizontal paddles for the top or bottom team
+func (gs *GameState) assignRowPaddles(paddles []*Paddle, team protocol.Team, width int) {
+	count := len(paddles)
+	if count == 0 {
+		return
+	}
+
+	// Determine row range for team
+	var rowStart, rowEnd int
+	if team == protocol.TeamTop {
+		rowStart = 1
+		rowEnd = gs.Height / 4
+	} else {
+		rowStart = gs.Height * 3 / 4
+		rowEnd = gs.Height - 1
+	}
+
+	// Distribute rows evenly
+	rowSpacing := (rowEnd - rowStart) / (count + 1)
+	if rowSpacing < 1 {
+		rowSpacing = 1
+	}
+
+	for i, p := range paddles {
+		p.Team = team
+		p.Horizontal = true
+		p.Column = rowStart + (i+1)*rowSpacing
+		p.Height = width
+		p.NormalHeight = width
+		p.CourtHeight = gs.Width
+		p.Step = gs.Rules.PaddleStep
+		p.Smoothing = gs.Rules.PaddleSmoothing
+		centerX := float64(gs.Width) / 2
+		p.Y = centerX
+		p.TargetY = centerX
+	}
+	gs.assignZones(paddles)
+}
+
+// launchFrom resets the ball at center, as if hit by the given team
+func (gs *GameState) launchFrom(team protocol.Team) {
+	gs.LastServer = team
+	switch team {
+	case protocol.TeamTop:
+		gs.launchBalls(0, 1)
+	case protocol.TeamBottom:
+		gs.launchBalls(0, -1)
+	default:
+		gs.launchBall(team == protocol.TeamLeft)
+		return
+	}
+	gs.LastHitTeam = team
+}
+
+// isWall returns true if the ball bounces off the team's edge instead of scoring
+func (gs *GameState) isWall(team protocol.Team) bool {
+	if !gs.FourWay {
+		return team == protocol.TeamTop || team == protocol.TeamBottom
+	}
+	return gs.Out[team]
+}
+
+// bounceOffClosedSides bounces a ball off the left and right edges of teams
+// that are out of a four-way match
+func (gs *GameState) bounceOffClosedSides(ball *Ball) {
+	if !gs.FourWay {
+		return
+	}
+	if ball.X < 0 && ball.VX < 0 && gs.Out[protocol.TeamLeft] {
+		ball.X = -ball.X
+		ball.VX = -ball.VX
+	}
+	if ball.X > float64(gs.Width) && ball.VX > 0 && gs.Out[protocol.TeamRight] {
+		ball.X = 2*float64(gs.Width) - ball.X
+		ball.VX = -ball.VX
+	}
+}
+
+// concedingTeam returns the team whose edge the ball crossed, if any
+func (gs *GameState) concedingTeam(ball *Ball) (protocol.Team, bool) {
+	switch {
+	case ball.X < 0:
+		return protocol.TeamLeft, true
+	case ball.X > float64(gs.Width):
+		return protocol.TeamRight, true
+	case gs.FourWay && ball.Y < 0:
+		return protocol.TeamTop, true
+	case gs.FourWay && ball.Y > float64(gs.Height):
+		return protocol.TeamBottom, true
+	}
+	return protocol.TeamLeft, false
+}
+
+// scoreFourWay records a goal against a team. The point goes to the team
+// that last touched the ball, and nobody scores on an own goal.
+func (gs *GameState) scoreFourWay(conceding protocol.Team) protocol.Team {
+	gs.Conceded[conceding]++
+	gs.LastConceded = conceding
+	if gs.Conceded[conceding] >= gs.PointsToWin {
+		gs.Out[conceding] = true
+	}
+
+	scorer := gs.LastHitTeam
+	if scorer != conceding {
+		gs.SideScores[scorer]++
+	}
+	gs.LeftScore = gs.SideScores[protocol.TeamLeft]
+	gs.RightScore = gs.SideScores[protocol.TeamRight]
+	return scorer
+}
+
+// nextServer picks who serves after a four-way goal: the team that conceded,
+// or the scorer if the conceding team is out
+func (gs *GameState) nextServer() protocol.Team {
+	if !gs.FourWay {
+		return gs.LastScorer
+	}
+	return gs.firstInMatch(gs.LastConceded, gs.LastScorer)
+}
+
+// fourWayResult returns the winner of a four-way match, if it is over
+func (gs *GameState) fourWayResult() (protocol.Team, protocol.MatchDecision, bool) {
+	for _, team := range FourWayTeams {
+		if gs.SideScores[team] >= gs.PointsToWin {
+			return team, protocol.DecidedByPoints, true
+		}
+	}
+
+	var alive []protocol.Team
+	for _, team := range FourWayTeams {
+		if !gs.Out[team] {
+			alive = append(alive, team)
+		}
+	}
+	if len(alive) == 1 {
+		return alive[0], protocol.DecidedByLastStanding, true
+	}
+	return protocol.TeamLeft, protocol.DecidedByPoints, false
+}
+
+// SideStates returns every team's four-way score, or nil in a normal match
+func (gs *GameState) SideStates() []protocol.SideState {
+	if !gs.FourWay {
+		return nil
+	}
+	sides := make([]protocol.SideState, 0, len(FourWayTeams))
+	for _, team := range FourWayTeams {
+		sides = append(sides, protocol.SideState{
+			Team:     team,
+			Score:    gs.SideScores[team],
+			Conceded: gs.Conceded[team],
+			Out:      gs.Out[team],
+		})
+	}
+	return sides
+}
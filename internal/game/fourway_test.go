@@ -0,0 +1,229 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// newFourWayGame creates a four-way game with one player per given team
+func newFourWayGame(teams ...protocol.Team) *GameState {
+	gs := NewGameState(80, 24, 3)
+	gs.FourWay = true
+	prefs := make(map[int]protocol.TeamPreference)
+	for i, team := range teams {
+		gs.AddPlayer(i+1, "Player")
+		prefs[i+1] = PreferenceFor(team)
+	}
+	gs.AssignTeamsByPreference(prefs)
+	return gs
+}
+
+func TestResolveFourTeams(t *testing.T) {
+	teams := ResolveFourTeams([]int{1, 2, 3, 4}, map[int]protocol.TeamPreference{1: protocol.PreferBottom})
+
+	// Picks are kept, random players fill the empty teams
+	if len(teams[protocol.TeamBottom]) != 1 || teams[protocol.TeamBottom][0] != 1 {
+		t.Errorf("expected player 1 alone on the bottom team, got %v", teams[protocol.TeamBottom])
+	}
+	for _, team := range FourWayTeams {
+		if len(teams[team]) != 1 {
+			t.Errorf("expected one player on team %d, got %v", team, teams[team])
+		}
+	}
+}
+
+func TestResolveFourTeams_FewPlayers(t *testing.T) {
+	teams := ResolveFourTeams([]int{1, 2, 3}, nil)
+
+	if len(teams[protocol.TeamBottom]) != 0 {
+		t.Errorf("expected nobody on the bottom team, got %v", teams[protocol.TeamBottom])
+	}
+	for _, team := range FourWayTeams[:3] {
+		if len(teams[team]) != 1 {
+			t.Errorf("expected one player on team %d, got %v", team, teams[team])
+		}
+	}
+}
+
+func TestBalanceFourByRating(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	ratings := map[int]float64{1: 1800, 2: 1700, 3: 1600, 4: 1500, 5: 1400, 6: 1300, 7: 1200, 8: 1100}
+
+	balanced := BalanceFourByRating(ids, ratings)
+
+	// Snake draft: the strongest and weakest end up together
+	if balanced[1] != balanced[8] || balanced[4] != balanced[5] {
+		t.Errorf("expected snake draft pairs, got %v", balanced)
+	}
+	counts := make(map[protocol.TeamPreference]int)
+	for _, pref := range balanced {
+		counts[pref]++
+	}
+	if len(counts) != 4 {
+		t.Errorf("expected players on all four teams, got %v", counts)
+	}
+}
+
+func TestFourWay_AssignTeams(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamRight, protocol.TeamTop)
+
+	top := gs.GetPaddle(3)
+	if top.Team != protocol.TeamTop || !top.Horizontal {
+		t.Fatalf("expected a horizontal top paddle, got team %d horizontal %v", top.Team, top.Horizontal)
+	}
+	if top.Column < 1 || top.Column > gs.Height/4 {
+		t.Errorf("expected top paddle row in the top quarter, got %d", top.Column)
+	}
+	if top.CourtHeight != gs.Width {
+		t.Errorf("expected top paddle to move across the court width, got %d", top.CourtHeight)
+	}
+
+	// Nobody on the bottom: its edge is a wall from the start
+	if !gs.Out[protocol.TeamBottom] || gs.Out[protocol.TeamTop] {
+		t.Errorf("expected only the bottom team out, got %v", gs.Out)
+	}
+}
+
+func TestFourWay_HorizontalPaddleInput(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamTop)
+	top := gs.GetPaddle(2)
+	startX := top.TargetY
+
+	gs.ProcessInput(2, protocol.DirRight)
+	if top.TargetY <= startX {
+		t.Errorf("expected right to move the top paddle right, got %f from %f", top.TargetY, startX)
+	}
+
+	// Up and down don't move a horizontal paddle
+	moved := top.TargetY
+	gs.ProcessInput(2, protocol.DirUp)
+	if top.TargetY != moved {
+		t.Errorf("expected up to be ignored, got %f from %f", top.TargetY, moved)
+	}
+}
+
+func TestFourWay_RowPaddleBounce(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamTop)
+	top := gs.GetPaddle(2)
+	top.Y = 40
+
+	gs.Ball.X = 40
+	gs.Ball.Y = float64(top.Column) + 0.5
+	gs.Ball.VX = 0.2
+	gs.Ball.VY = -0.5
+	gs.checkPaddleCollisions(gs.Ball)
+
+	if gs.Ball.VY <= 0 {
+		t.Errorf("expected ball to bounce down off the top paddle, got VY=%f", gs.Ball.VY)
+	}
+	if gs.LastHitTeam != protocol.TeamTop {
+		t.Errorf("expected top team to have last hit, got %d", gs.LastHitTeam)
+	}
+}
+
+func TestFourWay_GoalOnTopEdge(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamRight, protocol.TeamTop)
+	gs.LastHitTeam = protocol.TeamRight
+
+	gs.Ball.X = 40
+	gs.Ball.Y = -0.5
+	gs.Ball.VY = -0.5
+	gs.CheckScore()
+
+	if gs.Conceded[protocol.TeamTop] != 1 {
+		t.Errorf("expected top team to concede, got %v", gs.Conceded)
+	}
+	if gs.SideScores[protocol.TeamRight] != 1 || gs.RightScore != 1 {
+		t.Errorf("expected point for the right team, got %v", gs.SideScores)
+	}
+	if !gs.Paused {
+		t.Error("expected pause after a goal")
+	}
+}
+
+func TestFourWay_OwnGoalScoresNothing(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamRight, protocol.TeamTop)
+	gs.LastHitTeam = protocol.TeamLeft
+
+	gs.Ball.X = -0.5
+	gs.CheckScore()
+
+	if gs.Conceded[protocol.TeamLeft] != 1 {
+		t.Errorf("expected left team to concede, got %v", gs.Conceded)
+	}
+	for _, team := range FourWayTeams {
+		if gs.SideScores[team] != 0 {
+			t.Errorf("expected no points on an own goal, got %v", gs.SideScores)
+		}
+	}
+}
+
+func TestFourWay_KnockedOutTeamBecomesWall(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamRight, protocol.TeamTop)
+	gs.Conceded[protocol.TeamTop] = gs.PointsToWin - 1
+	gs.LastHitTeam = protocol.TeamLeft
+
+	gs.Ball.Y = -0.5
+	gs.CheckScore()
+
+	if !gs.Out[protocol.TeamTop] {
+		t.Fatal("expected top team to be out")
+	}
+
+	// The ball now bounces off the top edge
+	gs.Paused = false
+	gs.Ball.X = 40
+	gs.Ball.Y = 0.3
+	gs.Ball.VX = 0
+	gs.Ball.VY = -0.5
+	gs.moveBall(gs.Ball)
+	if gs.Ball.VY <= 0 {
+		t.Errorf("expected ball to bounce off the closed top edge, got VY=%f", gs.Ball.VY)
+	}
+}
+
+func TestFourWay_LastTeamStanding(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamRight, protocol.TeamTop)
+	gs.Out[protocol.TeamLeft] = true
+	gs.Out[protocol.TeamTop] = true
+
+	winner, decision, over := gs.MatchResult()
+	if !over || winner != protocol.TeamRight || decision != protocol.DecidedByLastStanding {
+		t.Errorf("expected right team to win as last standing, got %d %d %v", winner, decision, over)
+	}
+}
+
+func TestFourWay_NextServer(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamRight, protocol.TeamTop)
+	gs.LastScorer = protocol.TeamRight
+	gs.LastConceded = protocol.TeamTop
+
+	if got := gs.nextServer(); got != protocol.TeamTop {
+		t.Errorf("expected the conceding team to serve, got %d", got)
+	}
+
+	gs.Out[protocol.TeamTop] = true
+	if got := gs.nextServer(); got != protocol.TeamRight {
+		t.Errorf("expected the scorer to serve once the conceding team is out, got %d", got)
+	}
+}
+
+func TestFourWay_ProtocolSides(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamTop)
+
+	state := gs.ToProtocolState()
+	if len(state.Sides) != 4 {
+		t.Fatalf("expected 4 sides, got %d", len(state.Sides))
+	}
+	if !state.Sides[protocol.TeamRight].Out {
+		t.Error("expected the empty right team to be out")
+	}
+	if !state.Paddles[1].Horizontal {
+		t.Error("expected the top paddle to be horizontal")
+	}
+
+	if sides := NewGameState(80, 24, 3).SideStates(); sides != nil {
+		t.Errorf("expected no sides outside four-way mode, got %v", sides)
+	}
+}
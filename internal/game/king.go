@@ -0,0 +1,103 @@
+package game
+
+import "github.com/diegok/pixpong/internal/protocol"
+
+// KingQueue runs winner-stays-on: a fixed number of players on each side of
+// the court, and everyone else waiting in line for the losers' places
+type KingQueue struct {
+	PerSide int
+	Court   map[int]protocol.Team // Players on court and their side
+	Waiting []int                 // Players in line, next one first
+}
+
+// NewKingQueue creates an empty queue for the given players per side
+func NewKingQueue(perSide int) *KingQueue {
+	return &KingQueue{
+		PerSide: perSide,
+		Court:   make(map[int]protocol.Team),
+	}
+}
+
+// Sync drops players who left, puts newcomers at the back of the line and
+// fills both sides from the front. ids are all players, in join order.
+func (q *KingQueue) Sync(ids []int) {
+	q.Queue(ids)
+	for _, team := range []protocol.Team{protocol.TeamLeft, protocol.TeamRight} {
+		for q.sideSize(team) < q.PerSide && len(q.Waiting) > 0 {
+			q.Court[q.Waiting[0]] = team
+			q.Waiting = q.Waiting[1:]
+		}
+	}
+}
+
+// Queue drops players who left and puts newcomers at the back of the line,
+// leaving the court as it is while a match is played
+func (q *KingQueue) Queue(ids []int) {
+	present := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		present[id] = true
+	}
+	for id := range q.Court {
+		if !present[id] {
+			delete(q.Court, id)
+		}
+	}
+
+	waiting := make([]int, 0, len(ids))
+	queued := make(map[int]bool, len(ids))
+	for _, id := range q.Waiting {
+		if present[id] {
+			waiting = append(waiting, id)
+			queued[id] = true
+		}
+	}
+	for _, id := range ids {
+		if _, onCourt := q.Court[id]; !onCourt && !queued[id] {
+			waiting = append(waiting, id)
+		}
+	}
+	q.Waiting = waiting
+}
+
+// Rotate sends the losing side to the back of the line and brings the next
+// players on in their place. The winners keep their side.
+func (q *KingQueue) Rotate(ids []int, winner protocol.Team) {
+	for _, id := range ids {
+		if team, onCourt := q.Court[id]; onCourt && team != winner {
+			delete(q.Court, id)
+			q.Waiting = append(q.Waiting, id)
+		}
+	}
+	q.Sync(ids)
+}
+
+// Picks returns the side of every player on court
+func (q *KingQueue) Picks() map[int]protocol.TeamPreference {
+	picks := make(map[int]protocol.TeamPreference, len(q.Court))
+	for id, team := range q.Court {
+		picks[id] = PreferenceFor(team)
+	}
+	return picks
+}
+
+// Position returns a player's place in line, starting at 1, or 0 if they
+// are on court
+func (q *KingQueue) Position(id int) int {
+	for i, waiting := range q.Waiting {
+		if waiting == id {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// sideSize counts the players on one side
+func (q *KingQueue) sideSize(team protocol.Team) int {
+	n := 0
+	for _, t := range q.Court {
+		if t == team {
+			n++
+		}
+	}
+	return n
+}
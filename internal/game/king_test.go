@@ -0,0 +1,78 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+func TestKingQueue_FillsSidesInJoinOrder(t *testing.T) {
+	q := NewKingQueue(2)
+	q.Sync([]int{1, 2, 3, 4, 5, 6})
+
+	for id, want := range map[int]protocol.Team{1: protocol.TeamLeft, 2: protocol.TeamLeft, 3: protocol.TeamRight, 4: protocol.TeamRight} {
+		if team, ok := q.Court[id]; !ok || team != want {
+			t.Errorf("expected player %d on team %d, got %d (on court: %v)", id, want, team, ok)
+		}
+	}
+	if len(q.Waiting) != 2 || q.Position(5) != 1 || q.Position(6) != 2 {
+		t.Errorf("expected 5 and 6 waiting in order, got %v", q.Waiting)
+	}
+	if q.Position(1) != 0 {
+		t.Errorf("expected player on court to have no place in line, got %d", q.Position(1))
+	}
+}
+
+func TestKingQueue_LosersRotateOut(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5}
+	q := NewKingQueue(1)
+	q.Sync(ids)
+
+	// Left wins, right goes to the back of the line
+	q.Rotate(ids, protocol.TeamLeft)
+	if q.Court[1] != protocol.TeamLeft {
+		t.Errorf("expected winner to stay on the left, got %v", q.Court)
+	}
+	if team, ok := q.Court[3]; !ok || team != protocol.TeamRight {
+		t.Errorf("expected next in line to take the right side, got %v", q.Court)
+	}
+	if want := []int{4, 5, 2}; len(q.Waiting) != 3 || q.Waiting[0] != want[0] || q.Waiting[2] != want[2] {
+		t.Errorf("expected line %v, got %v", want, q.Waiting)
+	}
+}
+
+func TestKingQueue_SyncDropsLeaversAndQueuesNewcomers(t *testing.T) {
+	q := NewKingQueue(1)
+	q.Sync([]int{1, 2, 3})
+
+	// Player 2 leaves, 4 joins: 3 steps up, 4 waits
+	q.Sync([]int{1, 3, 4})
+	if team, ok := q.Court[3]; !ok || team != protocol.TeamRight {
+		t.Errorf("expected player 3 to replace the leaver, got %v", q.Court)
+	}
+	if len(q.Waiting) != 1 || q.Waiting[0] != 4 {
+		t.Errorf("expected only player 4 waiting, got %v", q.Waiting)
+	}
+
+	picks := q.Picks()
+	if picks[1] != protocol.PreferLeft || picks[3] != protocol.PreferRight || len(picks) != 2 {
+		t.Errorf("expected picks for the two players on court, got %v", picks)
+	}
+}
+
+func TestKingQueue_QueueKeepsCourt(t *testing.T) {
+	q := NewKingQueue(2)
+	q.Sync([]int{1, 2, 3})
+
+	// Mid-match a newcomer waits even though the right side has room
+	q.Queue([]int{1, 2, 3, 4})
+	if _, ok := q.Court[4]; ok || q.Position(4) != 1 {
+		t.Errorf("expected newcomer in line, got court %v line %v", q.Court, q.Waiting)
+	}
+
+	// After the match the free place is filled from the line
+	q.Sync([]int{1, 2, 3, 4})
+	if team, ok := q.Court[4]; !ok || team != protocol.TeamRight {
+		t.Errorf("expected newcomer on the right, got court %v", q.Court)
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// LateralMode controls whether paddles can move toward and away from the net
+type LateralMode int
+
+const (
+	LateralOff  LateralMode = iota // Paddles stay on their column
+	LateralHalf                    // Anywhere in the team's half of the court
+	LateralLane                    // Each player has a lane in the team's half
+)
+
+// ParseLateralMode converts a lateral mode name to a LateralMode
+func ParseLateralMode(name string) (LateralMode, error) {
+	switch name {
+	case "off", "":
+		return LateralOff, nil
+	case "half":
+		return LateralHalf, nil
+	case "lane":
+		return LateralLane, nil
+	}
+	return LateralOff, fmt.Errorf("unknown lateral mode %q", name)
+}
+
+// String returns the lateral mode name
+func (m LateralMode) String() string {
+	switch m {
+	case LateralHalf:
+		return "half"
+	case LateralLane:
+		return "lane"
+	}
+	return "off"
+}
+
+// teamZone returns the columns a team may move across, or rows for the top
+// and bottom teams. The net and the goal line are kept clear.
+func (gs *GameState) teamZone(team protocol.Team) (int, int) {
+	switch team {
+	case protocol.TeamLeft:
+		return 1, gs.Width/2 - 1
+	case protocol.TeamRight:
+		return gs.Width/2 + 1, gs.Width - 2
+	case protocol.TeamTop:
+		return 1, gs.Height/2 - 1
+	}
+	return gs.Height/2 + 1, gs.Height - 2
+}
+
+// assignZones sets how far each paddle of a team may move toward the net
+func (gs *GameState) assignZones(paddles []*Paddle) {
+	if gs.Lateral == LateralOff || len(paddles) == 0 {
+		return
+	}
+
+	zoneStart, zoneEnd := gs.teamZone(paddles[0].Team)
+	if gs.Lateral == LateralHalf {
+		for _, p := range paddles {
+			p.MinColumn, p.MaxColumn = zoneStart, zoneEnd
+		}
+		return
+	}
+
+	// Lanes follow the starting order, so nobody has to cross a teammate
+	ordered := append([]*Paddle(nil), paddles...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Column < ordered[j].Column
+	})
+	size := zoneEnd - zoneStart + 1
+	for i, p := range ordered {
+		p.MinColumn = zoneStart + i*size/len(ordered)
+		p.MaxColumn = zoneStart + (i+1)*size/len(ordered) - 1
+		p.Column = max(p.MinColumn, min(p.MaxColumn, p.Column))
+	}
+}
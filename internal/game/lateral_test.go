@@ -0,0 +1,105 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+func TestParseLateralMode(t *testing.T) {
+	for _, mode := range []LateralMode{LateralOff, LateralHalf, LateralLane} {
+		parsed, err := ParseLateralMode(mode.String())
+		if err != nil || parsed != mode {
+			t.Errorf("expected %q to parse to %d, got %d (%v)", mode, mode, parsed, err)
+		}
+	}
+	if _, err := ParseLateralMode("sideways"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestLateral_OffKeepsColumn(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.AddPlayer(1, "Alice")
+	gs.AddPlayer(2, "Bob")
+	gs.AssignTeams()
+
+	p := gs.GetPaddle(1)
+	column := p.Column
+	gs.ProcessInput(1, protocol.DirRight)
+	if p.Column != column {
+		t.Errorf("expected column %d to stay fixed, got %d", column, p.Column)
+	}
+}
+
+func TestLateral_HalfZone(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.Lateral = LateralHalf
+	gs.AddPlayer(1, "Alice")
+	gs.AddPlayer(2, "Bob")
+	gs.AssignTeamsByPreference(map[int]protocol.TeamPreference{1: protocol.PreferLeft, 2: protocol.PreferRight})
+
+	left := gs.GetPaddle(1)
+	column := left.Column
+	gs.ProcessInput(1, protocol.DirRight)
+	if left.Column != column+1 {
+		t.Errorf("expected column %d, got %d", column+1, left.Column)
+	}
+
+	// The net stops the paddle
+	for i := 0; i < gs.Width; i++ {
+		gs.ProcessInput(1, protocol.DirRight)
+	}
+	if left.Column != gs.Width/2-1 {
+		t.Errorf("expected paddle to stop at column %d, got %d", gs.Width/2-1, left.Column)
+	}
+
+	right := gs.GetPaddle(2)
+	for i := 0; i < gs.Width; i++ {
+		gs.ProcessInput(2, protocol.DirLeft)
+	}
+	if right.Column != gs.Width/2+1 {
+		t.Errorf("expected right paddle to stop at column %d, got %d", gs.Width/2+1, right.Column)
+	}
+}
+
+func TestLateral_Lanes(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.Lateral = LateralLane
+	prefs := make(map[int]protocol.TeamPreference)
+	for id := 1; id <= 3; id++ {
+		gs.AddPlayer(id, "Player")
+		prefs[id] = protocol.PreferLeft
+	}
+	gs.AddPlayer(4, "Other")
+	gs.AssignTeamsByPreference(prefs)
+
+	// Lanes split the half without overlapping, each paddle inside its own
+	lastMax := 0
+	for id := 1; id <= 3; id++ {
+		p := gs.GetPaddle(id)
+		if p.MinColumn <= lastMax {
+			t.Errorf("paddle %d lane %d-%d overlaps the previous lane", id, p.MinColumn, p.MaxColumn)
+		}
+		if p.Column < p.MinColumn || p.Column > p.MaxColumn {
+			t.Errorf("paddle %d at column %d outside its lane %d-%d", id, p.Column, p.MinColumn, p.MaxColumn)
+		}
+		lastMax = p.MaxColumn
+	}
+	if lastMax != gs.Width/2-1 {
+		t.Errorf("expected lanes to reach the net at %d, got %d", gs.Width/2-1, lastMax)
+	}
+}
+
+func TestLateral_RowPaddle(t *testing.T) {
+	gs := newFourWayGame(protocol.TeamLeft, protocol.TeamTop)
+	gs.Lateral = LateralHalf
+	gs.AssignTeamsByPreference(map[int]protocol.TeamPreference{1: protocol.PreferLeft, 2: protocol.PreferTop})
+
+	top := gs.GetPaddle(2)
+	row := top.Column
+	gs.ProcessInput(2, protocol.DirDown)
+	if top.Column != row+1 {
+		t.Errorf("expected down to move the top paddle to row %d, got %d", row+1, top.Column)
+	}
+}
@@ -0,0 +1,135 @@
+package game
+
+import "github.com/diegok/pixpong/internal/protocol"
+
+// MatchFormat holds the optional rules that decide when a match ends
+type MatchFormat struct {
+	WinByTwo  bool // A game needs a two-point lead once deuce is reached
+	TimeLimit int  // Ticks of play before the clock runs out (0 = no limit)
+	Sets      int  // Best of N sets (0 or 1 = a single game)
+}
+
+// SetsToWin returns how many sets a team needs to win the match
+func (f MatchFormat) SetsToWin() int {
+	if f.Sets <= 1 {
+		return 1
+	}
+	return f.Sets/2 + 1
+}
+
+// setWinner returns the team that has won the current game or set, if any
+func (gs *GameState) setWinner() (protocol.Team, bool) {
+	neededLead := 1
+	if gs.Format.WinByTwo {
+		neededLead = 2
+	}
+
+	lead := gs.LeftScore - gs.RightScore
+	if gs.LeftScore >= gs.PointsToWin && lead >= neededLead {
+		return protocol.TeamLeft, true
+	}
+	if gs.RightScore >= gs.PointsToWin && -lead >= neededLead {
+		return protocol.TeamRight, true
+	}
+	return protocol.TeamLeft, false
+}
+
+// checkSetOver awards the set in best-of-N matches. Returns true if a set
+// was won, in which case the rally ends and the points start over.
+func (gs *GameState) checkSetOver() bool {
+	if gs.Format.Sets <= 1 {
+		return false
+	}
+
+	winner, ok := gs.setWinner()
+	if !ok {
+		return false
+	}
+
+	if winner == protocol.TeamLeft {
+		gs.LeftSets++
+	} else {
+		gs.RightSets++
+	}
+	gs.SetJustEnded = true
+
+	// Keep the final set score if that set decided the match
+	if gs.LeftSets < gs.Format.SetsToWin() && gs.RightSets < gs.Format.SetsToWin() {
+		gs.LeftScore = 0
+		gs.RightScore = 0
+	}
+	return true
+}
+
+// leader returns the team ahead on sets, then points. False if level.
+func (gs *GameState) leader() (protocol.Team, bool) {
+	switch {
+	case gs.LeftSets > gs.RightSets:
+		return protocol.TeamLeft, true
+	case gs.RightSets > gs.LeftSets:
+		return protocol.TeamRight, true
+	case gs.LeftScore > gs.RightScore:
+		return protocol.TeamLeft, true
+	case gs.RightScore > gs.LeftScore:
+		return protocol.TeamRight, true
+	}
+	return protocol.TeamLeft, false
+}
+
+// updateClock advances the match clock and starts overtime on a tie
+func (gs *GameState) updateClock() {
+	if gs.Format.TimeLimit <= 0 {
+		return
+	}
+
+	gs.PlayTicks++
+	if gs.PlayTicks >= gs.Format.TimeLimit && !gs.Overtime {
+		if _, ahead := gs.leader(); !ahead {
+			gs.Overtime = true
+		}
+	}
+}
+
+// TimeLeft returns the remaining ticks on the match clock
+func (gs *GameState) TimeLeft() int {
+	left := gs.Format.TimeLimit - gs.PlayTicks
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// MatchResult returns the winner and how the match was decided, if it is over
+func (gs *GameState) MatchResult() (protocol.Team, protocol.MatchDecision, bool) {
+	if gs.FourWay {
+		return gs.fourWayResult()
+	}
+
+	if gs.Format.Sets > 1 {
+		if gs.LeftSets >= gs.Format.SetsToWin() {
+			return protocol.TeamLeft, protocol.DecidedBySets, true
+		}
+		if gs.RightSets >= gs.Format.SetsToWin() {
+			return protocol.TeamRight, protocol.DecidedBySets, true
+		}
+	} else if winner, ok := gs.setWinner(); ok {
+		// Both teams reaching game point means the deuce rule decided it
+		deuce := gs.LeftScore >= gs.PointsToWin-1 && gs.RightScore >= gs.PointsToWin-1
+		if gs.Format.WinByTwo && deuce {
+			return winner, protocol.DecidedByTwoPointLead, true
+		}
+		return winner, protocol.DecidedByPoints, true
+	}
+
+	// Clock ran out: whoever is ahead wins, otherwise overtime goes on
+	if gs.Format.TimeLimit > 0 && gs.PlayTicks >= gs.Format.TimeLimit {
+		if winner, ahead := gs.leader(); ahead {
+			if gs.Overtime {
+				return winner, protocol.DecidedByOvertime, true
+			}
+			return winner, protocol.DecidedByTime, true
+		}
+	}
+
+	return protocol.TeamLeft, protocol.DecidedByPoints, false
+}
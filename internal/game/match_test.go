@@ -0,0 +1,152 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+func TestMatchFormat_SetsToWin(t *testing.T) {
+	tests := []struct {
+		sets int
+		want int
+	}{
+		{0, 1},
+		{1, 1},
+		{3, 2},
+		{5, 3},
+	}
+
+	for _, tt := range tests {
+		got := MatchFormat{Sets: tt.sets}.SetsToWin()
+		if got != tt.want {
+			t.Errorf("SetsToWin() with %d sets = %d, want %d", tt.sets, got, tt.want)
+		}
+	}
+}
+
+func TestGameState_WinByTwo(t *testing.T) {
+	gs := NewGameState(80, 24, 5)
+	gs.Format = MatchFormat{WinByTwo: true}
+
+	gs.LeftScore = 5
+	gs.RightScore = 4
+	if gs.IsGameOver() {
+		t.Fatal("expected deuce to need a two-point lead")
+	}
+
+	gs.LeftScore = 6
+	winner, decision, over := gs.MatchResult()
+	if !over {
+		t.Fatal("expected match over with a two-point lead")
+	}
+	if winner != protocol.TeamLeft {
+		t.Errorf("expected TeamLeft to win")
+	}
+	if decision != protocol.DecidedByTwoPointLead {
+		t.Errorf("expected DecidedByTwoPointLead, got %d", decision)
+	}
+
+	// A clean win never reaches deuce
+	gs.LeftScore = 5
+	gs.RightScore = 2
+	if _, decision, _ := gs.MatchResult(); decision != protocol.DecidedByPoints {
+		t.Errorf("expected DecidedByPoints, got %d", decision)
+	}
+}
+
+func TestGameState_BestOfSets(t *testing.T) {
+	gs := NewGameState(80, 24, 3)
+	gs.AddPlayer(1, "P1")
+	gs.AddPlayer(2, "P2")
+	gs.AssignTeams()
+	gs.Format = MatchFormat{Sets: 3}
+
+	scoreLeft := func() {
+		gs.Paused = false
+		gs.Ball.X = float64(gs.Width) + 1
+		gs.CheckScore()
+	}
+
+	// First set: points reset once it is won
+	for i := 0; i < 3; i++ {
+		scoreLeft()
+	}
+	if gs.LeftSets != 1 {
+		t.Fatalf("expected LeftSets=1, got %d", gs.LeftSets)
+	}
+	if gs.LeftScore != 0 || gs.RightScore != 0 {
+		t.Errorf("expected scores reset after a set, got %d-%d", gs.LeftScore, gs.RightScore)
+	}
+	if !gs.SetJustEnded {
+		t.Error("expected SetJustEnded after winning a set")
+	}
+	if gs.IsGameOver() {
+		t.Fatal("match should not be over after one set")
+	}
+
+	// Second set decides the match and keeps the final set score
+	for i := 0; i < 3; i++ {
+		scoreLeft()
+	}
+	winner, decision, over := gs.MatchResult()
+	if !over || winner != protocol.TeamLeft {
+		t.Fatalf("expected TeamLeft to win the match")
+	}
+	if decision != protocol.DecidedBySets {
+		t.Errorf("expected DecidedBySets, got %d", decision)
+	}
+	if gs.LeftScore != 3 {
+		t.Errorf("expected final set score kept, got %d", gs.LeftScore)
+	}
+}
+
+func TestGameState_TimeLimit(t *testing.T) {
+	t.Run("leader wins when time runs out", func(t *testing.T) {
+		gs := NewGameState(80, 24, 10)
+		gs.Format = MatchFormat{TimeLimit: 10}
+		gs.RightScore = 2
+
+		for i := 0; i < 10; i++ {
+			gs.updateClock()
+		}
+
+		winner, decision, over := gs.MatchResult()
+		if !over || winner != protocol.TeamRight {
+			t.Fatalf("expected TeamRight to win on time")
+		}
+		if decision != protocol.DecidedByTime {
+			t.Errorf("expected DecidedByTime, got %d", decision)
+		}
+		if gs.TimeLeft() != 0 {
+			t.Errorf("expected no time left, got %d", gs.TimeLeft())
+		}
+	})
+
+	t.Run("tie goes to sudden-death overtime", func(t *testing.T) {
+		gs := NewGameState(80, 24, 10)
+		gs.Format = MatchFormat{TimeLimit: 10}
+		gs.LeftScore = 1
+		gs.RightScore = 1
+
+		for i := 0; i < 20; i++ {
+			gs.updateClock()
+		}
+
+		if !gs.Overtime {
+			t.Fatal("expected overtime on a tie")
+		}
+		if gs.IsGameOver() {
+			t.Fatal("match should go on while tied in overtime")
+		}
+
+		gs.LeftScore++
+		winner, decision, over := gs.MatchResult()
+		if !over || winner != protocol.TeamLeft {
+			t.Fatalf("expected the overtime goal to win")
+		}
+		if decision != protocol.DecidedByOvertime {
+			t.Errorf("expected DecidedByOvertime, got %d", decision)
+		}
+	})
+}
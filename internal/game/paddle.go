@@ -3,27 +3,40 @@ package game
 import "github.com/diegok/pixpong/internal/protocol"
 
 const (
-	PaddleTargetStep = 2.5  // How far target moves per input
-	PaddleSmoothSpeed = 0.4 // How fast paddle moves toward target (0-1, higher = faster)
+	PaddleTargetStep = 2.5  // Default for how far target moves per input
+	PaddleSmoothSpeed = 0.4 // Default for how fast paddle moves toward target (0-1, higher = faster)
 )
 
+// Paddle is a player's paddle. Horizontal paddles guard the top and bottom
+// edges in four-way mode: Column is then their row, Y is the position along
+// the row, Height is their width and CourtHeight the court width.
 type Paddle struct {
-	ID          int
-	Team        protocol.Team
-	Column      int // X position (fixed)
-	Y           float64
-	TargetY     float64 // Target position for smooth movement
-	Height      int
-	Color       int
-	CourtHeight int
+	ID           int
+	Team         protocol.Team
+	Column       int // X position
+	MinColumn    int // Lateral movement range, unused when MaxColumn <= MinColumn
+	MaxColumn    int
+	Y            float64
+	TargetY      float64 // Target position for smooth movement
+	Height       int
+	NormalHeight int // Height before power-up effects
+	Color        int
+	CourtHeight  int
+	Reversed     bool    // Controls swapped by a power-up
+	Horizontal   bool    // Moves left and right along a row
+	Velocity     float64 // How far Y moved on the last update
+	Step         float64 // How far the target moves per input
+	Smoothing    float64 // How fast the paddle catches up with its target
 }
 
 func NewPaddle(id int, team protocol.Team, column int, color int) *Paddle {
 	return &Paddle{
-		ID:     id,
-		Team:   team,
-		Column: column,
-		Color:  color,
+		ID:        id,
+		Team:      team,
+		Column:    column,
+		Color:     color,
+		Step:      PaddleTargetStep,
+		Smoothing: PaddleSmoothSpeed,
 	}
 }
 
@@ -33,25 +46,63 @@ func (p *Paddle) ProcessInput(dir protocol.Direction) {
 	minY := halfHeight
 	maxY := float64(p.CourtHeight) - halfHeight
 
+	// Horizontal paddles move along their row with left and right, and
+	// between rows with up and down
+	if p.Horizontal {
+		switch dir {
+		case protocol.DirLeft:
+			dir = protocol.DirUp
+		case protocol.DirRight:
+			dir = protocol.DirDown
+		case protocol.DirUp:
+			dir = protocol.DirLeft
+		case protocol.DirDown:
+			dir = protocol.DirRight
+		}
+	}
+
+	if p.Reversed {
+		switch dir {
+		case protocol.DirUp:
+			dir = protocol.DirDown
+		case protocol.DirDown:
+			dir = protocol.DirUp
+		}
+	}
+
 	switch dir {
 	case protocol.DirUp:
-		p.TargetY -= PaddleTargetStep
+		p.TargetY -= p.Step
 		if p.TargetY < minY {
 			p.TargetY = minY
 		}
 	case protocol.DirDown:
-		p.TargetY += PaddleTargetStep
+		p.TargetY += p.Step
 		if p.TargetY > maxY {
 			p.TargetY = maxY
 		}
+	case protocol.DirLeft:
+		p.moveColumn(-1)
+	case protocol.DirRight:
+		p.moveColumn(1)
 	}
 }
 
+// moveColumn moves the paddle toward or away from the net, within its zone
+func (p *Paddle) moveColumn(delta int) {
+	if p.MaxColumn <= p.MinColumn {
+		return // Fixed column
+	}
+	p.Column = max(p.MinColumn, min(p.MaxColumn, p.Column+delta))
+}
+
 // Update smoothly moves paddle toward target position
 func (p *Paddle) Update() {
+	previousY := p.Y
+
 	// Smooth interpolation toward target
 	diff := p.TargetY - p.Y
-	p.Y += diff * PaddleSmoothSpeed
+	p.Y += diff * p.Smoothing
 
 	// Clamp to bounds
 	halfHeight := float64(p.Height) / 2
@@ -62,6 +113,9 @@ func (p *Paddle) Update() {
 	if p.Y > maxY {
 		p.Y = maxY
 	}
+
+	// Remember how fast the paddle moved, for spin on the next hit
+	p.Velocity = p.Y - previousY
 }
 
 func (p *Paddle) ContainsY(y float64) bool {
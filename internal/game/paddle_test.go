@@ -180,3 +180,24 @@ func TestPaddle_ProcessInput_None(t *testing.T) {
 		t.Errorf("expected TargetY unchanged with DirNone, was %f, now %f", initialTarget, paddle.TargetY)
 	}
 }
+
+func TestPaddle_Update_TracksVelocity(t *testing.T) {
+	paddle := NewPaddle(1, protocol.TeamLeft, 2, 1)
+	paddle.Height = 6
+	paddle.CourtHeight = 24
+	paddle.Y = 12.0
+	paddle.TargetY = 16.0
+
+	paddle.Update()
+
+	if paddle.Velocity <= 0 || paddle.Velocity != paddle.Y-12.0 {
+		t.Errorf("expected velocity %f, got %f", paddle.Y-12.0, paddle.Velocity)
+	}
+
+	// Standing still has no velocity
+	paddle.TargetY = paddle.Y
+	paddle.Update()
+	if paddle.Velocity != 0 {
+		t.Errorf("expected no velocity, got %f", paddle.Velocity)
+	}
+}
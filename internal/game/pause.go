@@ -0,0 +1,80 @@
+package game
+
+import "github.com/diegok/pixpong/internal/protocol"
+
+// ResumeCountdown is how long play waits once a resume vote passes
+const ResumeCountdown = TickRate * 3
+
+// RequestPause freezes the match for everyone, if the player's team has
+// pauses left
+func (gs *GameState) RequestPause(playerID int) bool {
+	paddle := gs.GetPaddle(playerID)
+	if paddle == nil || gs.PausedBy != 0 || gs.PausesLeft(paddle.Team) <= 0 {
+		return false
+	}
+
+	gs.PausedBy = playerID
+	gs.PausesUsed[paddle.Team]++
+	gs.ResumeTicks = 0
+	gs.resumeVotes = make(map[int]bool)
+	return true
+}
+
+// VoteResume records a player's vote to play on. The player who paused can
+// resume alone, anyone else needs a majority of the humans. Bots don't vote.
+// Play restarts after a countdown.
+func (gs *GameState) VoteResume(playerID int) bool {
+	if gs.PausedBy == 0 || gs.ResumeTicks > 0 || gs.GetPaddle(playerID) == nil || gs.isBot(playerID) {
+		return false
+	}
+
+	gs.resumeVotes[playerID] = true
+	if playerID == gs.PausedBy || gs.ResumeVotes() >= gs.VotesNeeded() {
+		gs.ResumeTicks = ResumeCountdown
+	}
+	return true
+}
+
+// ResumeVotes returns how many human players voted to resume
+func (gs *GameState) ResumeVotes() int {
+	return len(gs.resumeVotes)
+}
+
+// VotesNeeded returns how many votes resume the match without the player
+// who paused: a majority of the humans
+func (gs *GameState) VotesNeeded() int {
+	humans := 0
+	for _, player := range gs.Players {
+		if !player.Bot && gs.GetPaddle(player.ID) != nil {
+			humans++
+		}
+	}
+	return humans/2 + 1
+}
+
+// isBot returns true if the player is a computer player
+func (gs *GameState) isBot(playerID int) bool {
+	for _, player := range gs.Players {
+		if player.ID == playerID {
+			return player.Bot
+		}
+	}
+	return false
+}
+
+// PausesLeft returns how many more pauses a team may call this match
+func (gs *GameState) PausesLeft(team protocol.Team) int {
+	return max(0, gs.PauseLimit-gs.PausesUsed[team])
+}
+
+// tickResume counts down to the end of a player pause
+func (gs *GameState) tickResume() {
+	if gs.ResumeTicks <= 0 {
+		return
+	}
+	gs.ResumeTicks--
+	if gs.ResumeTicks == 0 {
+		gs.PausedBy = 0
+		gs.resumeVotes = nil
+	}
+}
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+func TestPause_FreezesMatch(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	gs.PauseLimit = 1
+
+	if !gs.RequestPause(1) {
+		t.Fatal("expected pause to be granted")
+	}
+	x, y, tick := gs.Ball.X, gs.Ball.Y, gs.Tick
+	paddle := gs.GetPaddle(2)
+	target := paddle.TargetY
+	gs.ProcessInput(2, protocol.DirDown)
+	for i := 0; i < TickRate; i++ {
+		gs.Update()
+	}
+	if gs.Ball.X != x || gs.Ball.Y != y || gs.Tick != tick {
+		t.Error("expected the ball and clock to stay frozen while paused")
+	}
+	if paddle.TargetY != target {
+		t.Error("expected paddle input to be ignored while paused")
+	}
+}
+
+func TestPause_Limit(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	gs.PauseLimit = 1
+	team := gs.GetPaddle(1).Team
+
+	if !gs.RequestPause(1) {
+		t.Fatal("expected first pause to be granted")
+	}
+	if gs.RequestPause(2) {
+		t.Error("expected no pause while already paused")
+	}
+	gs.VoteResume(1)
+	for gs.PausedBy != 0 {
+		gs.Update()
+	}
+
+	if gs.PausesLeft(team) != 0 {
+		t.Errorf("expected no pauses left, got %d", gs.PausesLeft(team))
+	}
+	if gs.RequestPause(1) {
+		t.Error("expected pause to be refused once the team used its pauses")
+	}
+	if !gs.RequestPause(2) {
+		t.Error("expected the other team to still have a pause")
+	}
+}
+
+func TestPause_PauserResumes(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	gs.PauseLimit = 2
+	gs.RequestPause(1)
+
+	gs.VoteResume(1)
+	if gs.ResumeTicks != ResumeCountdown {
+		t.Fatalf("expected the resume countdown to start, got %d ticks", gs.ResumeTicks)
+	}
+	for i := 0; i < ResumeCountdown-1; i++ {
+		gs.Update()
+	}
+	if gs.PausedBy == 0 {
+		t.Fatal("expected play to wait for the end of the countdown")
+	}
+	gs.Update()
+	if gs.PausedBy != 0 {
+		t.Error("expected play to resume after the countdown")
+	}
+}
+
+func TestPause_MajorityResumes(t *testing.T) {
+	gs := newServeGame(ServeStandard, 4)
+	gs.PauseLimit = 1
+	gs.RequestPause(1)
+
+	gs.VoteResume(2)
+	gs.VoteResume(2)
+	if gs.ResumeTicks != 0 {
+		t.Fatal("expected one vote not to resume")
+	}
+	gs.VoteResume(3)
+	if gs.ResumeTicks != 0 {
+		t.Fatal("expected two of four votes not to resume")
+	}
+	gs.VoteResume(4)
+	if gs.ResumeTicks != ResumeCountdown {
+		t.Error("expected a majority to resume without the player who paused")
+	}
+}
+
+func TestPause_BotsDontVote(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.PauseLimit = 1
+	gs.AddPlayer(1, "Human")
+	prefs := map[int]protocol.TeamPreference{1: protocol.PreferLeft}
+	for id := 2; id <= 4; id++ {
+		gs.AddBot(id, "Bot")
+		prefs[id] = protocol.PreferRight
+	}
+	gs.AssignTeamsByPreference(prefs)
+
+	if gs.VotesNeeded() != 1 {
+		t.Errorf("expected bots left out of the majority, got %d votes needed", gs.VotesNeeded())
+	}
+	gs.RequestPause(1)
+	for id := 2; id <= 4; id++ {
+		if gs.VoteResume(id) {
+			t.Errorf("expected bot %d's vote to be refused", id)
+		}
+	}
+	for i := 0; i < ResumeCountdown*2; i++ {
+		gs.Update()
+	}
+	if gs.PausedBy == 0 || gs.ResumeVotes() != 0 {
+		t.Fatal("expected the match to stay paused for the human")
+	}
+
+	gs.VoteResume(1)
+	if gs.ResumeTicks != ResumeCountdown {
+		t.Error("expected the human to resume the match")
+	}
+}
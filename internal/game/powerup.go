@@ -0,0 +1,233 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// Constants for the power-up subsystem
+const (
+	PowerUpSpawnInterval = TickRate * 6  // A new item every 6 seconds of play
+	PowerUpLifetime      = TickRate * 12 // Items vanish if nobody takes them
+	PowerUpDuration      = TickRate * 8  // Timed effects last 8 seconds
+	MaxPowerUpsOnCourt   = 2
+	PowerUpRadius        = 1.0 // How close the ball must pass to take an item
+	PowerUpSpeedBoost    = 1.3 // Ball speed multiplier for the speed item
+	PaddleGrowAmount     = 2
+	PaddleShrinkAmount   = 2
+)
+
+// powerUpKinds lists every kind that can spawn
+var powerUpKinds = []protocol.PowerUpKind{
+	protocol.PowerUpGrow,
+	protocol.PowerUpShrink,
+	protocol.PowerUpSpeed,
+	protocol.PowerUpReverse,
+	protocol.PowerUpExtraBall,
+	protocol.PowerUpShield,
+}
+
+// PowerUp is an item waiting on the court
+type PowerUp struct {
+	ID        int
+	Kind      protocol.PowerUpKind
+	X, Y      float64
+	TicksLeft int
+}
+
+// Effect is a timed power-up effect applied to one team
+type Effect struct {
+	Kind      protocol.PowerUpKind
+	Team      protocol.Team
+	TicksLeft int
+}
+
+// SpawnPowerUp places a new item at a random spot in the middle of the court
+func (gs *GameState) SpawnPowerUp(kind protocol.PowerUpKind) *PowerUp {
+	gs.nextPowerUpID++
+
+	// Middle quarter of the court, away from the walls
+	minX := float64(gs.Width) * 3 / 8
+	maxX := float64(gs.Width) * 5 / 8
+	minY := 2.0
+	maxY := float64(gs.Height) - 2
+
+	item := &PowerUp{
+		ID:        gs.nextPowerUpID,
+		Kind:      kind,
+		X:         minX + rand.Float64()*(maxX-minX),
+		Y:         minY + rand.Float64()*(maxY-minY),
+		TicksLeft: PowerUpLifetime,
+	}
+	gs.PowerUps = append(gs.PowerUps, item)
+	return item
+}
+
+// updatePowerUps spawns items, lets balls take them and ticks effects
+func (gs *GameState) updatePowerUps() {
+	// Spawn on a fixed interval while there is room on the court
+	gs.powerUpSpawnTicks++
+	if gs.powerUpSpawnTicks >= PowerUpSpawnInterval {
+		gs.powerUpSpawnTicks = 0
+		if len(gs.PowerUps) < MaxPowerUpsOnCourt {
+			gs.SpawnPowerUp(powerUpKinds[rand.Intn(len(powerUpKinds))])
+		}
+	}
+
+	// Collect items the balls passed through, and expire old ones
+	kept := gs.PowerUps[:0]
+	for _, item := range gs.PowerUps {
+		if ball := gs.ballThrough(item); ball != nil {
+			gs.applyPowerUp(item.Kind, ball)
+			gs.PowerUpsCollected++
+			continue
+		}
+		item.TicksLeft--
+		if item.TicksLeft > 0 {
+			kept = append(kept, item)
+		}
+	}
+	gs.PowerUps = kept
+
+	// Tick timed effects
+	active := gs.Effects[:0]
+	for _, effect := range gs.Effects {
+		effect.TicksLeft--
+		if effect.TicksLeft > 0 {
+			active = append(active, effect)
+		}
+	}
+	gs.Effects = active
+}
+
+// ballThrough returns the ball that passed through the item this tick, if any
+func (gs *GameState) ballThrough(item *PowerUp) *Ball {
+	for _, ball := range gs.balls() {
+		// Check the whole path travelled this tick so fast balls can't skip items
+		if segmentDistance(item.X, item.Y, ball.X-ball.VX, ball.Y-ball.VY, ball.X, ball.Y) <= PowerUpRadius {
+			return ball
+		}
+	}
+	return nil
+}
+
+// applyPowerUp triggers an item, credited to the team that last hit the ball
+func (gs *GameState) applyPowerUp(kind protocol.PowerUpKind, ball *Ball) {
+	team := gs.LastHitTeam
+
+	switch kind {
+	case protocol.PowerUpGrow, protocol.PowerUpShield:
+		gs.addEffect(kind, team)
+	case protocol.PowerUpShrink, protocol.PowerUpReverse:
+		for _, opponent := range gs.opponentsOf(team) {
+			gs.addEffect(kind, opponent)
+		}
+	case protocol.PowerUpSpeed:
+		ball.SpeedUp(PowerUpSpeedBoost)
+	case protocol.PowerUpExtraBall:
+		gs.addBall(ball.X, ball.Y, team == protocol.TeamLeft)
+	}
+}
+
+// addEffect starts a timed effect, refreshing it if already active
+func (gs *GameState) addEffect(kind protocol.PowerUpKind, team protocol.Team) {
+	for _, effect := range gs.Effects {
+		if effect.Kind == kind && effect.Team == team {
+			effect.TicksLeft = PowerUpDuration
+			return
+		}
+	}
+	gs.Effects = append(gs.Effects, &Effect{Kind: kind, Team: team, TicksLeft: PowerUpDuration})
+}
+
+// HasEffect returns true if the effect is active for the team
+func (gs *GameState) HasEffect(kind protocol.PowerUpKind, team protocol.Team) bool {
+	for _, effect := range gs.Effects {
+		if effect.Kind == kind && effect.Team == team {
+			return true
+		}
+	}
+	return false
+}
+
+// applyEffects updates paddle size and controls from the active effects
+func (gs *GameState) applyEffects() {
+	for _, p := range gs.Paddles {
+		height := p.NormalHeight
+		if gs.HasEffect(protocol.PowerUpGrow, p.Team) {
+			height += PaddleGrowAmount
+		}
+		if gs.HasEffect(protocol.PowerUpShrink, p.Team) {
+			height -= PaddleShrinkAmount
+		}
+		if height < 1 {
+			height = 1
+		}
+		p.Height = height
+		p.Reversed = gs.HasEffect(protocol.PowerUpReverse, p.Team)
+	}
+}
+
+// checkShields bounces a ball off a shielded goal line
+func (gs *GameState) checkShields(ball *Ball) {
+	if ball.X < 0 && ball.VX < 0 && gs.HasEffect(protocol.PowerUpShield, protocol.TeamLeft) {
+		ball.X = -ball.X
+		ball.VX = -ball.VX
+		gs.ShieldBlocks++
+	}
+	if ball.X > float64(gs.Width) && ball.VX > 0 && gs.HasEffect(protocol.PowerUpShield, protocol.TeamRight) {
+		ball.X = 2*float64(gs.Width) - ball.X
+		ball.VX = -ball.VX
+		gs.ShieldBlocks++
+	}
+	if !gs.FourWay {
+		return
+	}
+	if ball.Y < 0 && ball.VY < 0 && gs.HasEffect(protocol.PowerUpShield, protocol.TeamTop) {
+		ball.Y = -ball.Y
+		ball.VY = -ball.VY
+		gs.ShieldBlocks++
+	}
+	if ball.Y > float64(gs.Height) && ball.VY > 0 && gs.HasEffect(protocol.PowerUpShield, protocol.TeamBottom) {
+		ball.Y = 2*float64(gs.Height) - ball.Y
+		ball.VY = -ball.VY
+		gs.ShieldBlocks++
+	}
+}
+
+// opponentsOf returns the teams still playing against the given team
+func (gs *GameState) opponentsOf(team protocol.Team) []protocol.Team {
+	if !gs.FourWay {
+		return []protocol.Team{otherTeam(team)}
+	}
+	var opponents []protocol.Team
+	for _, other := range FourWayTeams {
+		if other != team && !gs.Out[other] {
+			opponents = append(opponents, other)
+		}
+	}
+	return opponents
+}
+
+// otherTeam returns the opposing team
+func otherTeam(team protocol.Team) protocol.Team {
+	if team == protocol.TeamLeft {
+		return protocol.TeamRight
+	}
+	return protocol.TeamLeft
+}
+
+// segmentDistance returns the distance from point (px, py) to the segment (ax, ay)-(bx, by)
+func segmentDistance(px, py, ax, ay, bx, by float64) float64 {
+	dx := bx - ax
+	dy := by - ay
+	lengthSq := dx*dx + dy*dy
+	t := 0.0
+	if lengthSq > 0 {
+		t = ((px-ax)*dx + (py-ay)*dy) / lengthSq
+		t = math.Max(0, math.Min(1, t))
+	}
+	return math.Hypot(px-(ax+t*dx), py-(ay+t*dy))
+}
@@ -0,0 +1,204 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// newPowerUpGame creates a 1v1 game with power-ups enabled and the ball parked
+func newPowerUpGame() *GameState {
+	gs := NewGameState(80, 24, 10)
+	gs.AddPlayer(1, "P1")
+	gs.AddPlayer(2, "P2")
+	gs.AssignTeams()
+	gs.PowerUpsEnabled = true
+	gs.Ball = NewBall(40, 12)
+	return gs
+}
+
+func TestGameState_SpawnPowerUp(t *testing.T) {
+	gs := newPowerUpGame()
+
+	item := gs.SpawnPowerUp(protocol.PowerUpGrow)
+	if len(gs.PowerUps) != 1 {
+		t.Fatalf("expected 1 power-up, got %d", len(gs.PowerUps))
+	}
+	if item.X < float64(gs.Width)*3/8 || item.X > float64(gs.Width)*5/8 {
+		t.Errorf("power-up X=%f should be in the middle of the court", item.X)
+	}
+	if item.Y < 0 || item.Y > float64(gs.Height) {
+		t.Errorf("power-up Y=%f out of court", item.Y)
+	}
+
+	second := gs.SpawnPowerUp(protocol.PowerUpShield)
+	if second.ID == item.ID {
+		t.Error("expected power-ups to have distinct IDs")
+	}
+}
+
+func TestGameState_PowerUpSpawnsOverTime(t *testing.T) {
+	gs := newPowerUpGame()
+	gs.Ball.VX = 0
+	gs.Ball.VY = 0
+	gs.Ball.Y = 0.5
+
+	for i := 0; i < PowerUpSpawnInterval; i++ {
+		gs.updatePowerUps()
+	}
+
+	if len(gs.PowerUps) != 1 {
+		t.Errorf("expected a power-up after %d ticks, got %d", PowerUpSpawnInterval, len(gs.PowerUps))
+	}
+}
+
+func TestGameState_PowerUpCollectedByBall(t *testing.T) {
+	gs := newPowerUpGame()
+	gs.PowerUps = []*PowerUp{{ID: 1, Kind: protocol.PowerUpGrow, X: 41, Y: 12, TicksLeft: PowerUpLifetime}}
+	gs.LastHitTeam = protocol.TeamLeft
+
+	// Ball travels through the item in one tick
+	gs.Ball.X = 42
+	gs.Ball.VX = 2
+	gs.Ball.VY = 0
+
+	gs.updatePowerUps()
+
+	if len(gs.PowerUps) != 0 {
+		t.Errorf("expected power-up to be taken")
+	}
+	if gs.PowerUpsCollected != 1 {
+		t.Errorf("expected PowerUpsCollected=1, got %d", gs.PowerUpsCollected)
+	}
+	if !gs.HasEffect(protocol.PowerUpGrow, protocol.TeamLeft) {
+		t.Errorf("expected grow effect credited to the left team")
+	}
+}
+
+func TestGameState_PowerUpExpires(t *testing.T) {
+	gs := newPowerUpGame()
+	gs.PowerUps = []*PowerUp{{ID: 1, Kind: protocol.PowerUpGrow, X: 40, Y: 2, TicksLeft: 2}}
+	gs.Ball.Y = 20
+
+	gs.updatePowerUps()
+	gs.updatePowerUps()
+
+	if len(gs.PowerUps) != 0 {
+		t.Errorf("expected untouched power-up to expire")
+	}
+}
+
+func TestGameState_PaddleSizeEffects(t *testing.T) {
+	gs := newPowerUpGame()
+	gs.LastHitTeam = protocol.TeamLeft
+
+	gs.applyPowerUp(protocol.PowerUpGrow, gs.Ball)
+	gs.applyPowerUp(protocol.PowerUpShrink, gs.Ball)
+	gs.applyEffects()
+
+	for _, p := range gs.Paddles {
+		if p.Team == protocol.TeamLeft && p.Height != p.NormalHeight+PaddleGrowAmount {
+			t.Errorf("expected left paddle to grow to %d, got %d", p.NormalHeight+PaddleGrowAmount, p.Height)
+		}
+		if p.Team == protocol.TeamRight && p.Height != p.NormalHeight-PaddleShrinkAmount {
+			t.Errorf("expected right paddle to shrink to %d, got %d", p.NormalHeight-PaddleShrinkAmount, p.Height)
+		}
+	}
+
+	// Effects wear off
+	for i := 0; i < PowerUpDuration; i++ {
+		gs.updatePowerUps()
+	}
+	gs.applyEffects()
+
+	for _, p := range gs.Paddles {
+		if p.Height != p.NormalHeight {
+			t.Errorf("expected paddle height back to %d, got %d", p.NormalHeight, p.Height)
+		}
+	}
+}
+
+func TestGameState_ReverseEffect(t *testing.T) {
+	gs := newPowerUpGame()
+	gs.LastHitTeam = protocol.TeamLeft
+	gs.applyPowerUp(protocol.PowerUpReverse, gs.Ball)
+	gs.applyEffects()
+
+	for _, p := range gs.Paddles {
+		before := p.TargetY
+		gs.ProcessInput(p.ID, protocol.DirUp)
+		if p.Team == protocol.TeamRight && p.TargetY <= before {
+			t.Errorf("expected reversed right paddle to move down on DirUp")
+		}
+		if p.Team == protocol.TeamLeft && p.TargetY >= before {
+			t.Errorf("expected left paddle to move up on DirUp")
+		}
+	}
+}
+
+func TestGameState_ShieldBlocksGoal(t *testing.T) {
+	gs := newPowerUpGame()
+	gs.addEffect(protocol.PowerUpShield, protocol.TeamLeft)
+
+	gs.Ball.X = 0.2
+	gs.Ball.Y = 1
+	gs.Ball.VX = -0.5
+	gs.Ball.VY = 0
+	gs.Update()
+
+	if gs.RightScore != 0 {
+		t.Errorf("expected shield to stop the goal, RightScore=%d", gs.RightScore)
+	}
+	if gs.Ball.VX <= 0 {
+		t.Errorf("expected ball to bounce back off the shield, VX=%f", gs.Ball.VX)
+	}
+	if gs.ShieldBlocks != 1 {
+		t.Errorf("expected ShieldBlocks=1, got %d", gs.ShieldBlocks)
+	}
+}
+
+func TestGameState_ExtraBall(t *testing.T) {
+	gs := newPowerUpGame()
+	gs.LastHitTeam = protocol.TeamLeft
+	gs.applyPowerUp(protocol.PowerUpExtraBall, gs.Ball)
+
+	if len(gs.ExtraBalls) != 1 {
+		t.Fatalf("expected 1 extra ball, got %d", len(gs.ExtraBalls))
+	}
+	if gs.ExtraBalls[0].VX <= 0 {
+		t.Errorf("expected extra ball to head toward the right team")
+	}
+
+	// Extra ball scoring counts but keeps the rally going
+	gs.ExtraBalls[0].X = float64(gs.Width) + 1
+	gs.CheckScore()
+
+	if gs.LeftScore != 1 {
+		t.Errorf("expected LeftScore=1, got %d", gs.LeftScore)
+	}
+	if gs.Paused {
+		t.Errorf("extra ball goal should not pause the game")
+	}
+	if len(gs.ExtraBalls) != 0 {
+		t.Errorf("expected scored extra ball to leave the court")
+	}
+}
+
+func TestGameState_ToProtocolState_PowerUps(t *testing.T) {
+	gs := newPowerUpGame()
+	gs.SpawnPowerUp(protocol.PowerUpSpeed)
+	gs.addEffect(protocol.PowerUpShield, protocol.TeamRight)
+	gs.ExtraBalls = append(gs.ExtraBalls, NewBall(10, 10))
+
+	state := gs.ToProtocolState()
+
+	if len(state.PowerUps) != 1 || state.PowerUps[0].Kind != protocol.PowerUpSpeed {
+		t.Errorf("expected speed power-up in protocol state, got %+v", state.PowerUps)
+	}
+	if len(state.Effects) != 1 || state.Effects[0].SecondsLeft != PowerUpDuration/TickRate {
+		t.Errorf("expected shield effect with %ds left, got %+v", PowerUpDuration/TickRate, state.Effects)
+	}
+	if len(state.ExtraBalls) != 1 {
+		t.Errorf("expected 1 extra ball in protocol state, got %d", len(state.ExtraBalls))
+	}
+}
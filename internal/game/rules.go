@@ -0,0 +1,154 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// Rules holds the physics of a match. Every field has a default, so a rules
+// file only needs the values it changes.
+type Rules struct {
+	Name                  string  `json:"name"`
+	BallSpeed             float64 `json:"ball_speed"`               // Serve speed in cells per tick
+	MaxBounceAngle        float64 `json:"max_bounce_angle"`         // Degrees off straight for an edge hit
+	SpeedIncrement        float64 `json:"speed_increment"`          // Speed multiplier per paddle hit
+	BaseSpeedCap          float64 `json:"base_speed_cap"`           // Top speed with one player per team
+	SpeedCapPerPlayer     float64 `json:"speed_cap_per_player"`     // Extra top speed per additional player
+	PaddleStep            float64 `json:"paddle_step"`              // How far the paddle target moves per input
+	PaddleSmoothing       float64 `json:"paddle_smoothing"`         // How fast paddles catch up with the target (0-1)
+	PaddleHeight          int     `json:"paddle_height"`            // Paddle height with one player per team
+	MinPaddleHeight       int     `json:"min_paddle_height"`        // Smallest paddle, however big the team
+	PaddleHeightPerPlayer int     `json:"paddle_height_per_player"` // Height lost per additional player
+}
+
+// DefaultRules returns the classic rules
+func DefaultRules() Rules {
+	return Rules{
+		Name:                  "classic",
+		BallSpeed:             InitialBallSpeed,
+		MaxBounceAngle:        MaxBounceAngle * 180 / math.Pi,
+		SpeedIncrement:        SpeedIncrement,
+		BaseSpeedCap:          BaseSpeedCap,
+		SpeedCapPerPlayer:     SpeedCapPerPlayer,
+		PaddleStep:            PaddleTargetStep,
+		PaddleSmoothing:       PaddleSmoothSpeed,
+		PaddleHeight:          BasePaddleHeight,
+		MinPaddleHeight:       MinPaddleHeight,
+		PaddleHeightPerPlayer: PaddleHeightPerPlayer,
+	}
+}
+
+// RulePresets are the built-in rules that --rules accepts by name
+var RulePresets = map[string]Rules{
+	"classic": DefaultRules(),
+	"fast": func() Rules {
+		r := DefaultRules()
+		r.Name = "fast"
+		r.BallSpeed = 0.4
+		r.SpeedIncrement = 1.08
+		r.BaseSpeedCap = 2.0
+		r.PaddleStep = 3.0
+		r.PaddleSmoothing = 0.6
+		return r
+	}(),
+	"chill": func() Rules {
+		r := DefaultRules()
+		r.Name = "chill"
+		r.BallSpeed = 0.2
+		r.SpeedIncrement = 1.02
+		r.BaseSpeedCap = 0.8
+		r.SpeedCapPerPlayer = 0.15
+		r.PaddleHeight = 7
+		r.MinPaddleHeight = 4
+		return r
+	}(),
+	"wild": func() Rules {
+		r := DefaultRules()
+		r.Name = "wild"
+		r.MaxBounceAngle = 75
+		r.SpeedIncrement = 1.1
+		r.PaddleHeight = 4
+		r.MinPaddleHeight = 2
+		return r
+	}(),
+}
+
+// PresetNames returns the built-in rule preset names in order
+func PresetNames() []string {
+	names := make([]string, 0, len(RulePresets))
+	for name := range RulePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadRules returns a preset by name, or reads and validates a rules file
+func LoadRules(nameOrPath string) (Rules, error) {
+	if rules, ok := RulePresets[nameOrPath]; ok {
+		return rules, nil
+	}
+
+	data, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		return Rules{}, fmt.Errorf("failed to read rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules decodes and validates JSON rules on top of the defaults
+func ParseRules(data []byte) (Rules, error) {
+	rules := DefaultRules()
+	rules.Name = ""
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return Rules{}, fmt.Errorf("failed to parse rules: %w", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return Rules{}, err
+	}
+	return rules, nil
+}
+
+// Validate checks that the rules make a playable game
+func (r Rules) Validate() error {
+	if r.Name == "" {
+		return errors.New("rules need a name")
+	}
+	if r.BallSpeed <= 0 || r.BallSpeed > 2 {
+		return fmt.Errorf("ball_speed must be between 0 and 2, got %g", r.BallSpeed)
+	}
+	if r.MaxBounceAngle <= 0 || r.MaxBounceAngle > 85 {
+		return fmt.Errorf("max_bounce_angle must be between 0 and 85 degrees, got %g", r.MaxBounceAngle)
+	}
+	if r.SpeedIncrement < 1 || r.SpeedIncrement > 2 {
+		return fmt.Errorf("speed_increment must be between 1 and 2, got %g", r.SpeedIncrement)
+	}
+	if r.BaseSpeedCap < r.BallSpeed {
+		return fmt.Errorf("base_speed_cap must be at least ball_speed (%g), got %g", r.BallSpeed, r.BaseSpeedCap)
+	}
+	if r.SpeedCapPerPlayer < 0 {
+		return fmt.Errorf("speed_cap_per_player cannot be negative, got %g", r.SpeedCapPerPlayer)
+	}
+	if r.PaddleStep <= 0 {
+		return fmt.Errorf("paddle_step must be positive, got %g", r.PaddleStep)
+	}
+	if r.PaddleSmoothing <= 0 || r.PaddleSmoothing > 1 {
+		return fmt.Errorf("paddle_smoothing must be between 0 and 1, got %g", r.PaddleSmoothing)
+	}
+	if r.MinPaddleHeight < 1 || r.PaddleHeight < r.MinPaddleHeight {
+		return fmt.Errorf("paddle heights must be at least 1 and min_paddle_height (%d) at most paddle_height (%d)", r.MinPaddleHeight, r.PaddleHeight)
+	}
+	if r.PaddleHeightPerPlayer < 0 {
+		return fmt.Errorf("paddle_height_per_player cannot be negative, got %d", r.PaddleHeightPerPlayer)
+	}
+	return nil
+}
+
+// BounceAngle returns the maximum bounce angle in radians
+func (r Rules) BounceAngle() float64 {
+	return r.MaxBounceAngle * math.Pi / 180
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultRules_MatchConstants(t *testing.T) {
+	rules := DefaultRules()
+	if err := rules.Validate(); err != nil {
+		t.Fatalf("default rules are invalid: %v", err)
+	}
+	if rules.BallSpeed != InitialBallSpeed || rules.PaddleHeight != BasePaddleHeight {
+		t.Errorf("expected defaults to match the constants, got %+v", rules)
+	}
+	if math.Abs(rules.BounceAngle()-MaxBounceAngle) > 1e-9 {
+		t.Errorf("expected bounce angle %f, got %f", MaxBounceAngle, rules.BounceAngle())
+	}
+}
+
+func TestRulePresets_Valid(t *testing.T) {
+	for _, name := range PresetNames() {
+		rules, err := LoadRules(name)
+		if err != nil {
+			t.Fatalf("failed to load preset %q: %v", name, err)
+		}
+		if rules.Name != name {
+			t.Errorf("expected preset %q to be named after itself, got %q", name, rules.Name)
+		}
+		if err := rules.Validate(); err != nil {
+			t.Errorf("preset %q is invalid: %v", name, err)
+		}
+	}
+}
+
+func TestParseRules_OverridesDefaults(t *testing.T) {
+	rules, err := ParseRules([]byte(`{"name": "slow", "ball_speed": 0.15, "paddle_height": 8}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rules.Name != "slow" || rules.BallSpeed != 0.15 || rules.PaddleHeight != 8 {
+		t.Errorf("expected overrides to apply, got %+v", rules)
+	}
+	if rules.SpeedIncrement != SpeedIncrement {
+		t.Errorf("expected unset speed increment to keep default %f, got %f", SpeedIncrement, rules.SpeedIncrement)
+	}
+}
+
+func TestParseRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not json", `{`},
+		{"no name", `{"ball_speed": 0.3}`},
+		{"zero speed", `{"name": "x", "ball_speed": 0}`},
+		{"cap below speed", `{"name": "x", "ball_speed": 1.0, "base_speed_cap": 0.5}`},
+		{"slowing hits", `{"name": "x", "speed_increment": 0.9}`},
+		{"flat angle", `{"name": "x", "max_bounce_angle": 90}`},
+		{"tiny paddle", `{"name": "x", "min_paddle_height": 0}`},
+		{"min above height", `{"name": "x", "paddle_height": 3, "min_paddle_height": 4}`},
+		{"smoothing", `{"name": "x", "paddle_smoothing": 1.5}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRules([]byte(tt.data)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadRules_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(`{"name": "office", "max_bounce_angle": 45}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := LoadRules(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rules.Name != "office" || rules.MaxBounceAngle != 45 {
+		t.Errorf("expected rules from file, got %+v", rules)
+	}
+
+	if _, err := LoadRules(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestGameState_UsesRules(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.Rules = RulePresets["chill"]
+	gs.AddPlayer(1, "Alice")
+	gs.AddPlayer(2, "Bob")
+	gs.AssignTeams()
+
+	p := gs.GetPaddle(1)
+	if p.Height != gs.Rules.PaddleHeight {
+		t.Errorf("expected paddle height %d from rules, got %d", gs.Rules.PaddleHeight, p.Height)
+	}
+	if p.Step != gs.Rules.PaddleStep || p.Smoothing != gs.Rules.PaddleSmoothing {
+		t.Errorf("expected paddle movement from rules, got step %f smoothing %f", p.Step, p.Smoothing)
+	}
+
+	gs.WaitingForServe = true
+	gs.ServingTeam = p.Team
+	gs.Serve(1)
+	speed := math.Hypot(gs.Ball.VX, gs.Ball.VY)
+	if math.Abs(speed-gs.Rules.BallSpeed) > 1e-9 {
+		t.Errorf("expected serve speed %f, got %f", gs.Rules.BallSpeed, speed)
+	}
+}
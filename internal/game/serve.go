@@ -0,0 +1,211 @@
+package game
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// MaxServeAngle is the steepest aimed serve, from a paddle at the court edge
+const MaxServeAngle = math.Pi / 6
+
+// ServeRule decides who serves after a goal
+type ServeRule int
+
+const (
+	ServeStandard  ServeRule = iota // The scorer serves, in four-way the team that conceded
+	ServeConceding                  // The team that conceded serves
+	ServeAlternate                  // Teams take turns
+	ServeRotation                   // Every player takes a turn, switching teams each serve
+)
+
+// ParseServeRule converts a serve rule name to a ServeRule
+func ParseServeRule(name string) (ServeRule, error) {
+	switch name {
+	case "standard", "":
+		return ServeStandard, nil
+	case "conceding":
+		return ServeConceding, nil
+	case "alternate":
+		return ServeAlternate, nil
+	case "rotation":
+		return ServeRotation, nil
+	}
+	return ServeStandard, fmt.Errorf("unknown serve rule %q", name)
+}
+
+// String returns the serve rule name
+func (r ServeRule) String() string {
+	switch r {
+	case ServeConceding:
+		return "conceding"
+	case ServeAlternate:
+		return "alternate"
+	case ServeRotation:
+		return "rotation"
+	}
+	return "standard"
+}
+
+// startServe waits for the next serve, picking the server by the serve rule
+func (gs *GameState) startServe() {
+	gs.WaitingForServe = true
+	gs.ServingPlayer = 0
+	gs.ServeTicksLeft = gs.ServeTimeout
+
+	switch gs.ServeRule {
+	case ServeConceding:
+		gs.ServingTeam = gs.firstInMatch(gs.lastConceded(), gs.LastScorer)
+	case ServeAlternate:
+		gs.ServingTeam = gs.teamAfter(gs.LastServer)
+	case ServeRotation:
+		order := gs.serveRotation()
+		if len(order) == 0 {
+			gs.ServingTeam = gs.nextServer()
+			return
+		}
+		server := order[gs.serveTurn%len(order)]
+		gs.ServingTeam = server.Team
+		gs.ServingPlayer = server.ID
+	default:
+		gs.ServingTeam = gs.nextServer()
+	}
+}
+
+// tickServeClock counts down the serve clock and serves for the team when
+// it runs out
+func (gs *GameState) tickServeClock() {
+	if gs.ServeTimeout <= 0 {
+		return
+	}
+	gs.ServeTicksLeft--
+	if gs.ServeTicksLeft <= 0 {
+		gs.serveFrom(gs.GetPaddle(gs.ServingPlayer))
+	}
+}
+
+// serveFrom launches the ball away from the serving team, aimed by the
+// serving paddle if there is one
+func (gs *GameState) serveFrom(paddle *Paddle) {
+	gs.launchFrom(gs.ServingTeam)
+	if paddle != nil {
+		gs.aimServe(paddle)
+	}
+	gs.WaitingForServe = false
+	gs.SetJustEnded = false
+	gs.ServingPlayer = 0
+	gs.ServeTicksLeft = 0
+	gs.serveTurn++
+}
+
+// aimServe sends the ball toward the side the serving paddle stands on:
+// straight from the middle, at MaxServeAngle from the edge
+func (gs *GameState) aimServe(paddle *Paddle) {
+	center := float64(paddle.CourtHeight) / 2
+	reach := center - float64(paddle.Height)/2
+	if reach <= 0 {
+		return
+	}
+	offset := math.Max(-1, math.Min(1, (paddle.Y-center)/reach))
+
+	dirX, dirY := serveDirection(gs.ServingTeam)
+	angle := offset * MaxServeAngle * (dirX - dirY)
+	gs.Ball.LaunchAt(gs.Ball.X, gs.Ball.Y, dirX, dirY, angle, gs.Rules.BallSpeed)
+}
+
+// serveDirection returns the direction a team serves in
+func serveDirection(team protocol.Team) (float64, float64) {
+	switch team {
+	case protocol.TeamLeft:
+		return 1, 0
+	case protocol.TeamTop:
+		return 0, 1
+	case protocol.TeamBottom:
+		return 0, -1
+	}
+	return -1, 0
+}
+
+// serveTeams returns the teams still in the match, in serving order
+func (gs *GameState) serveTeams() []protocol.Team {
+	teams := []protocol.Team{protocol.TeamLeft, protocol.TeamRight}
+	if gs.FourWay {
+		teams = FourWayTeams
+	}
+
+	var alive []protocol.Team
+	for _, team := range teams {
+		if !gs.Out[team] {
+			alive = append(alive, team)
+		}
+	}
+	return alive
+}
+
+// firstInMatch returns the first of the given teams that is still in the
+// match, or any team that is
+func (gs *GameState) firstInMatch(teams ...protocol.Team) protocol.Team {
+	for _, team := range append(teams, gs.serveTeams()...) {
+		if !gs.Out[team] {
+			return team
+		}
+	}
+	return gs.LastScorer
+}
+
+// lastConceded returns the team that conceded the last goal
+func (gs *GameState) lastConceded() protocol.Team {
+	if gs.FourWay {
+		return gs.LastConceded
+	}
+	if gs.LastScorer == protocol.TeamLeft {
+		return protocol.TeamRight
+	}
+	return protocol.TeamLeft
+}
+
+// teamAfter returns the team that serves after the given one when teams
+// take turns
+func (gs *GameState) teamAfter(team protocol.Team) protocol.Team {
+	teams := gs.serveTeams()
+	if len(teams) == 0 {
+		return gs.LastScorer
+	}
+	for i, t := range teams {
+		if t == team {
+			return teams[(i+1)%len(teams)]
+		}
+	}
+	// The last server is out, so the next team still in takes over
+	for _, t := range teams {
+		if t > team {
+			return t
+		}
+	}
+	return teams[0]
+}
+
+// serveRotation returns every player in serving order: the first player of
+// each team, then the second, and so on
+func (gs *GameState) serveRotation() []*Paddle {
+	byTeam := make(map[protocol.Team][]*Paddle)
+	for _, p := range gs.Paddles {
+		byTeam[p.Team] = append(byTeam[p.Team], p)
+	}
+
+	var order []*Paddle
+	teams := gs.serveTeams()
+	for i := 0; ; i++ {
+		added := false
+		for _, team := range teams {
+			if i < len(byTeam[team]) {
+				order = append(order, byTeam[team][i])
+				added = true
+			}
+		}
+		if !added {
+			return order
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// newServeGame returns a two-team game waiting for a serve
+func newServeGame(rule ServeRule, players int) *GameState {
+	gs := NewGameState(80, 24, 10)
+	gs.ServeRule = rule
+	prefs := make(map[int]protocol.TeamPreference)
+	for id := 1; id <= players; id++ {
+		gs.AddPlayer(id, "Player")
+		prefs[id] = protocol.PreferLeft
+		if id%2 == 0 {
+			prefs[id] = protocol.PreferRight
+		}
+	}
+	gs.AssignTeamsByPreference(prefs)
+	return gs
+}
+
+func TestParseServeRule(t *testing.T) {
+	for _, rule := range []ServeRule{ServeStandard, ServeConceding, ServeAlternate, ServeRotation} {
+		parsed, err := ParseServeRule(rule.String())
+		if err != nil || parsed != rule {
+			t.Errorf("expected %q to parse to %d, got %d (%v)", rule, rule, parsed, err)
+		}
+	}
+	if _, err := ParseServeRule("random"); err == nil {
+		t.Error("expected error for unknown serve rule")
+	}
+}
+
+func TestServe_Rules(t *testing.T) {
+	tests := []struct {
+		rule       ServeRule
+		lastServer protocol.Team
+		want       protocol.Team
+	}{
+		{ServeStandard, protocol.TeamLeft, protocol.TeamRight},
+		{ServeConceding, protocol.TeamLeft, protocol.TeamLeft},
+		{ServeAlternate, protocol.TeamLeft, protocol.TeamRight},
+		{ServeAlternate, protocol.TeamRight, protocol.TeamLeft},
+	}
+	for _, tt := range tests {
+		gs := newServeGame(tt.rule, 2)
+		gs.LastScorer = protocol.TeamRight
+		gs.LastServer = tt.lastServer
+		gs.startServe()
+		if gs.ServingTeam != tt.want {
+			t.Errorf("%s rule after %d served: expected %d to serve, got %d", tt.rule, tt.lastServer, tt.want, gs.ServingTeam)
+		}
+	}
+}
+
+func TestServe_AlternateFromOpeningServe(t *testing.T) {
+	ids := map[protocol.Team]int{protocol.TeamLeft: 1, protocol.TeamRight: 2}
+	for _, openRight := range []bool{true, false} {
+		gs := newServeGame(ServeAlternate, 2)
+		gs.launchBall(openRight)
+		opener, other := protocol.TeamRight, protocol.TeamLeft
+		if openRight {
+			opener, other = protocol.TeamLeft, protocol.TeamRight
+		}
+
+		// Whoever scores, the serves go to the other team, then back
+		for _, want := range []protocol.Team{other, opener} {
+			gs.LastScorer = protocol.TeamLeft
+			gs.startServe()
+			if gs.ServingTeam != want {
+				t.Fatalf("opening serve from %d: expected %d to serve, got %d", opener, want, gs.ServingTeam)
+			}
+			if !gs.Serve(ids[want]) {
+				t.Fatalf("expected player %d to serve", ids[want])
+			}
+		}
+	}
+}
+
+func TestServe_Rotation(t *testing.T) {
+	gs := newServeGame(ServeRotation, 4)
+
+	// Left and right players take turns: 1, 2, 3, 4
+	for _, want := range []int{1, 2, 3, 4, 1} {
+		gs.startServe()
+		if gs.ServingPlayer != want {
+			t.Fatalf("expected player %d to serve, got %d", want, gs.ServingPlayer)
+		}
+		teammate := (want+1)%4 + 1
+		if gs.Serve(teammate) {
+			t.Fatalf("expected teammate %d not to serve on player %d's turn", teammate, want)
+		}
+		if !gs.Serve(want) {
+			t.Fatalf("expected player %d to serve", want)
+		}
+	}
+}
+
+func TestServe_Clock(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	gs.ServeTimeout = 3
+	gs.LastScorer = protocol.TeamLeft
+	gs.startServe()
+
+	gs.Update()
+	gs.Update()
+	if !gs.WaitingForServe {
+		t.Fatal("expected to keep waiting before the clock runs out")
+	}
+	gs.Update()
+	if gs.WaitingForServe {
+		t.Fatal("expected the ball to be served when the clock runs out")
+	}
+	if gs.Ball.VX <= 0 {
+		t.Errorf("expected the auto-serve to leave the left team, got VX %f", gs.Ball.VX)
+	}
+}
+
+func TestServe_NoClockWaits(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	gs.startServe()
+	for i := 0; i < TickRate*60; i++ {
+		gs.Update()
+	}
+	if !gs.WaitingForServe {
+		t.Error("expected to wait for the serve without a serve clock")
+	}
+}
+
+func TestServe_Aim(t *testing.T) {
+	for _, team := range []protocol.Team{protocol.TeamLeft, protocol.TeamRight} {
+		gs := newServeGame(ServeStandard, 2)
+		gs.LastScorer = team
+		gs.startServe()
+
+		var server *Paddle
+		for _, p := range gs.Paddles {
+			if p.Team == team {
+				server = p
+			}
+		}
+
+		// A paddle at the bottom serves downward, in the middle straight
+		server.Y = float64(server.CourtHeight) - float64(server.Height)/2
+		gs.Serve(server.ID)
+		if gs.Ball.VY <= 0 {
+			t.Errorf("team %d: expected a serve from the bottom to go down, got VY %f", team, gs.Ball.VY)
+		}
+
+		gs.startServe()
+		server.Y = float64(server.CourtHeight) / 2
+		gs.Serve(server.ID)
+		if gs.Ball.VY != 0 {
+			t.Errorf("team %d: expected a serve from the middle to go straight, got VY %f", team, gs.Ball.VY)
+		}
+	}
+}
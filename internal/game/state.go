@@ -7,12 +7,14 @@ import (
 	"github.com/diegok/pixpong/internal/protocol"
 )
 
-// Constants for game state management
+// Constants for game state management. The physics values are the
+// defaults for Rules, matches read them from GameState.Rules.
 const (
 	TickRate              = 60   // Ticks per second
 	BaseSpeedCap          = 1.5  // Base maximum ball speed
 	SpeedCapPerPlayer     = 0.3  // Additional speed cap per player
 	SpeedIncrement        = 1.05 // 5% speed increase per paddle hit
+	MaxBallsOnCourt       = 8    // Hard limit on simultaneous balls
 	BasePaddleHeight      = 5    // Default paddle height
 	MinPaddleHeight       = 3    // Minimum paddle height
 	PaddleHeightPerPlayer = 1    // Height reduction per additional player
@@ -22,6 +24,7 @@ const (
 type PlayerInfo struct {
 	ID   int
 	Name string
+	Bot  bool // Computer player, left out of resume votes
 }
 
 // GameState manages the complete game state
@@ -40,6 +43,56 @@ type GameState struct {
 	LastScorer     protocol.Team
 	WaitingForServe bool
 	ServingTeam    protocol.Team
+	ServingPlayer  int           // Player who must serve, 0 for anyone on the team
+	ServeRule      ServeRule     // Who serves after a goal
+	ServeTimeout   int           // Ticks before the ball is served automatically, 0 to wait forever
+	ServeTicksLeft int
+	LastServer     protocol.Team // Team that served the current rally
+	serveTurn      int
+	LastHitTeam    protocol.Team // Team that last touched the ball
+	Court          *Court        // Map obstacles, nil for an empty court
+	Lateral        LateralMode   // Whether paddles can leave their column
+	Rules          Rules         // Ball and paddle physics
+
+	// Match format
+	Format       MatchFormat
+	LeftSets     int
+	RightSets    int
+	PlayTicks    int  // Ticks counted against the time limit
+	Overtime     bool // Clock ran out on a tie, next goal wins
+	SetJustEnded bool // The last goal won a set
+
+	// Pauses called by players
+	PausedBy    int // Player who paused the match, 0 while it runs
+	PauseLimit  int // Pauses each team may call per match
+	PausesUsed  [4]int
+	ResumeTicks int // Countdown to play once a resume vote passed
+	resumeVotes map[int]bool
+
+	// Four-way mode, indexed by team
+	FourWay      bool
+	SideScores   [4]int
+	Conceded     [4]int
+	Out          [4]bool // Conceded PointsToWin goals, edge is now a wall
+	LastConceded protocol.Team
+
+	// Multi-ball
+	BallCount     int // Balls put in play on each serve
+	BallEvery     int // Add a ball every N paddle hits (0 = never)
+	hitsSinceBall int
+
+	// Power-ups
+	PowerUpsEnabled   bool
+	PowerUps          []*PowerUp
+	Effects           []*Effect
+	ExtraBalls        []*Ball
+	PowerUpsCollected int
+	ShieldBlocks      int
+	nextPowerUpID     int
+	powerUpSpawnTicks int
+
+	// Match statistics, for the stats screen
+	Stats MatchStats
 }
 
 // NewGameState creates a new game state with the given dimensions
@@ -49,6 +102,8 @@ func NewGameState(width, height, pointsToWin int) *GameState {
 		Height:      height,
 		PointsToWin: pointsToWin,
 		Ball:        NewBall(float64(width)/2, float64(height)/2),
+		BallCount:   1,
+		Rules:       DefaultRules(),
 		Paddles:     make([]*Paddle, 0),
 		Players:     make([]PlayerInfo, 0),
 	}
@@ -67,6 +122,13 @@ func (gs *GameState) AddPlayer(id int, name string) *Paddle {
 	return paddle
 }
 
+// AddBot adds a computer player and creates its paddle
+func (gs *GameState) AddBot(id int, name string) *Paddle {
+	paddle := gs.AddPlayer(id, name)
+	gs.Players[len(gs.Players)-1].Bot = true
+	return paddle
+}
+
 // GetPaddle returns the paddle for the given player ID
 func (gs *GameState) GetPaddle(id int) *Paddle {
 	for _, p := range gs.Paddles {
@@ -77,37 +139,58 @@ func (gs *GameState) GetPaddle(id int) *Paddle {
 	return nil
 }
 
-// AssignTeams randomly assigns players to teams and positions paddles
-func (gs *GameState) AssignTeams() {
-	if len(gs.Paddles) == 0 {
-		return
+// PlayerName returns the name of the given player, empty if unknown
+func (gs *GameState) PlayerName(id int) string {
+	for _, p := range gs.Players {
+		if p.ID == id {
+			return p.Name
+		}
 	}
+	return ""
+}
 
-	// Shuffle paddles randomly
-	shuffled := make([]*Paddle, len(gs.Paddles))
-	copy(shuffled, gs.Paddles)
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
-
-	// Split into teams
-	halfCount := len(shuffled) / 2
-	leftTeam := shuffled[:halfCount]
-	rightTeam := shuffled[halfCount:]
-
-	// Calculate paddle height per team - fewer players = bigger paddles
-	leftPaddleHeight := gs.CalculatePaddleHeight(len(leftTeam))
-	rightPaddleHeight := gs.CalculatePaddleHeight(len(rightTeam))
+// AssignTeams randomly assigns players to teams and positions paddles
+func (gs *GameState) AssignTeams() {
+	gs.AssignTeamsByPreference(nil)
+}
 
-	// Assign left team
-	gs.assignTeamPaddles(leftTeam, protocol.TeamLeft, leftPaddleHeight)
+// launchBall resets the ball at center, as if served by the team it leaves
+func (gs *GameState) launchBall(launchRight bool) {
+	if launchRight {
+		gs.launchBalls(1, 0)
+		gs.LastHitTeam = protocol.TeamLeft
+	} else {
+		gs.launchBalls(-1, 0)
+		gs.LastHitTeam = protocol.TeamRight
+	}
+	gs.LastServer = gs.LastHitTeam
+}
 
-	// Assign right team
-	gs.assignTeamPaddles(rightTeam, protocol.TeamRight, rightPaddleHeight)
+// launchBalls resets the ball at center and launches it along (dirX, dirY).
+// In multi-ball games the rest of the balls are launched with it.
+func (gs *GameState) launchBalls(dirX, dirY float64) {
+	centerX, centerY := float64(gs.Width)/2, float64(gs.Height)/2
+	gs.Ball.Launch(centerX, centerY, dirX, dirY, gs.Rules.BallSpeed)
+
+	gs.ExtraBalls = nil
+	gs.hitsSinceBall = 0
+	gs.startRally()
+	for i := 1; i < gs.BallCount; i++ {
+		if ball := gs.addBall(centerX, centerY, dirX > 0); ball != nil {
+			ball.Launch(centerX, centerY, dirX, dirY, gs.Rules.BallSpeed)
+		}
+	}
+}
 
-	// Initialize ball with velocity (launch toward random team)
-	launchRight := rand.Intn(2) == 0
-	gs.Ball.Reset(float64(gs.Width)/2, float64(gs.Height)/2, launchRight)
+// addBall puts another ball in play, unless the court is already full
+func (gs *GameState) addBall(x, y float64, launchRight bool) *Ball {
+	if len(gs.ExtraBalls)+1 >= MaxBallsOnCourt {
+		return nil
+	}
+	ball := NewBall(x, y)
+	ball.Reset(x, y, launchRight, gs.Rules.BallSpeed)
+	gs.ExtraBalls = append(gs.ExtraBalls, ball)
+	return ball
 }
 
 // assignTeamPaddles sets up paddles for a team
@@ -138,37 +221,47 @@ func (gs *GameState) assignTeamPaddles(paddles []*Paddle, team protocol.Team, he
 		p.Team = team
 		p.Column = columnStart + (i+1)*columnSpacing
 		p.Height = height
+		p.NormalHeight = height
 		p.CourtHeight = gs.Height
+		p.Step = gs.Rules.PaddleStep
+		p.Smoothing = gs.Rules.PaddleSmoothing
 		centerY := float64(gs.Height) / 2
 		p.Y = centerY
 		p.TargetY = centerY // Initialize target to current position
 	}
+	gs.assignZones(paddles)
 }
 
 // CalculatePaddleHeight computes paddle height based on players per side
 func (gs *GameState) CalculatePaddleHeight(playersPerSide int) int {
-	height := BasePaddleHeight - (playersPerSide-1)*PaddleHeightPerPlayer
-	if height < MinPaddleHeight {
-		height = MinPaddleHeight
+	height := gs.Rules.PaddleHeight - (playersPerSide-1)*gs.Rules.PaddleHeightPerPlayer
+	if height < gs.Rules.MinPaddleHeight {
+		height = gs.Rules.MinPaddleHeight
 	}
 	return height
 }
 
 // GetSpeedCap returns the maximum ball speed based on players per side
 func (gs *GameState) GetSpeedCap(playersPerSide int) float64 {
-	return BaseSpeedCap + float64(playersPerSide-1)*SpeedCapPerPlayer
+	return gs.Rules.BaseSpeedCap + float64(playersPerSide-1)*gs.Rules.SpeedCapPerPlayer
 }
 
 // ProcessInput handles player input - moves paddle immediately
 func (gs *GameState) ProcessInput(playerID int, dir protocol.Direction) {
 	paddle := gs.GetPaddle(playerID)
-	if paddle != nil {
+	if paddle != nil && gs.PausedBy == 0 {
 		paddle.ProcessInput(dir)
 	}
 }
 
 // Update runs one game tick
 func (gs *GameState) Update() {
+	// A player pause freezes everything, clocks included
+	if gs.PausedBy != 0 {
+		gs.tickResume()
+		return
+	}
+
 	gs.Tick++
 
 	// Handle pause state (brief pause after score)
@@ -178,8 +271,7 @@ func (gs *GameState) Update() {
 			gs.Paused = false
 			gs.PauseTicksLeft = 0
 			// Enter waiting for serve state
-			gs.WaitingForServe = true
-			gs.ServingTeam = gs.LastScorer
+			gs.startServe()
 			// Position ball at center
 			gs.Ball.X = float64(gs.Width) / 2
 			gs.Ball.Y = float64(gs.Height) / 2
@@ -189,6 +281,14 @@ func (gs *GameState) Update() {
 		return
 	}
 
+	// Run the match clock
+	gs.updateClock()
+
+	// Apply power-up effects to paddles
+	if gs.PowerUpsEnabled {
+		gs.applyEffects()
+	}
+
 	// Update paddle positions (smooth movement toward targets)
 	for _, p := range gs.Paddles {
 		p.Update()
@@ -196,73 +296,140 @@ func (gs *GameState) Update() {
 
 	// Handle waiting for serve - ball doesn't move, but paddles can
 	if gs.WaitingForServe {
+		gs.tickServeClock()
 		return
 	}
 
-	// Move ball
-	gs.Ball.Move()
-
-	// Check wall bounces (top/bottom)
-	if gs.Ball.Y <= 0 || gs.Ball.Y >= float64(gs.Height) {
-		gs.Ball.BounceVertical()
-		// Keep ball in bounds
-		if gs.Ball.Y < 0 {
-			gs.Ball.Y = 0
-		}
-		if gs.Ball.Y > float64(gs.Height) {
-			gs.Ball.Y = float64(gs.Height)
-		}
+	// Move balls
+	for _, ball := range gs.balls() {
+		gs.moveBall(ball)
 	}
 
-	// Check paddle collisions
-	gs.checkPaddleCollisions()
+	// Spawn and collect power-ups
+	if gs.PowerUpsEnabled {
+		gs.updatePowerUps()
+	}
 
 	// Check scoring
 	gs.CheckScore()
 }
 
+// balls returns every ball on the court, the main ball first
+func (gs *GameState) balls() []*Ball {
+	return append([]*Ball{gs.Ball}, gs.ExtraBalls...)
+}
+
+// moveBall advances a ball and resolves wall, shield and paddle contacts
+func (gs *GameState) moveBall(ball *Ball) {
+	ball.Move()
+
+	// Spin can't curve the ball into an endless up and down rally
+	if !gs.FourWay {
+		ball.LimitAngle(gs.Rules.BounceAngle())
+	}
+
+	// Check wall bounces (top/bottom, unless a four-way team guards them)
+	if ball.Y <= 0 && ball.VY < 0 && gs.isWall(protocol.TeamTop) {
+		ball.BounceVertical()
+		ball.Y = 0 // Keep ball in bounds
+	}
+	if ball.Y >= float64(gs.Height) && ball.VY > 0 && gs.isWall(protocol.TeamBottom) {
+		ball.BounceVertical()
+		ball.Y = float64(gs.Height)
+	}
+
+	// Check the edges of teams knocked out of a four-way match
+	gs.bounceOffClosedSides(ball)
+
+	// Check shielded goal lines
+	gs.checkShields(ball)
+
+	// Check map obstacles and closed goal lines
+	gs.collideCourt(ball)
+
+	// Check paddle collisions
+	gs.checkPaddleCollisions(ball)
+}
+
 // checkPaddleCollisions handles ball-paddle collisions
-func (gs *GameState) checkPaddleCollisions() {
+func (gs *GameState) checkPaddleCollisions(ball *Ball) {
 	for _, p := range gs.Paddles {
-		// Check if ball is at paddle column
-		ballCol := int(gs.Ball.X)
-		if ballCol != p.Column {
+		// Paddles of knocked out teams leave the court
+		if gs.FourWay && gs.Out[p.Team] {
 			continue
 		}
 
-		// Check if ball Y is within paddle
-		if !p.ContainsY(gs.Ball.Y) {
+		hit := gs.hitsColumnPaddle(ball, p)
+		if p.Horizontal {
+			hit = gs.hitsRowPaddle(ball, p)
+		}
+		if !hit {
 			continue
 		}
 
-		// Check direction - only collide if ball is moving toward paddle
-		if p.Team == protocol.TeamLeft && gs.Ball.VX > 0 {
-			continue // Ball moving away from left paddle
+		// Reaching a ball that was going in counts as a save
+		save := gs.isSave(ball, p)
+		if p.Horizontal {
+			ball.BounceOffRowPaddle(p.Y, p.Height, gs.Rules.BounceAngle())
+			ball.SpinFrom(p.Velocity, 0)
+		} else {
+			ball.BounceOffPaddle(p.Y, p.Height, gs.Rules.BounceAngle())
+			ball.SpinFrom(0, p.Velocity)
 		}
-		if p.Team == protocol.TeamRight && gs.Ball.VX < 0 {
-			continue // Ball moving away from right paddle
-		}
-
-		// Bounce off paddle
-		gs.Ball.BounceOffPaddle(p.Y, p.Height)
+		gs.LastHitTeam = p.Team
 
 		// Speed up ball
-		gs.Ball.SpeedUp(SpeedIncrement)
+		ball.SpeedUp(gs.Rules.SpeedIncrement)
 
 		// Cap speed based on player count
 		playersPerSide := gs.countPlayersOnSide(p.Team)
 		speedCap := gs.GetSpeedCap(playersPerSide)
-		if gs.Ball.Speed() > speedCap {
+		if ball.Speed() > speedCap {
 			// Scale down to cap
-			scale := speedCap / gs.Ball.Speed()
-			gs.Ball.VX *= scale
-			gs.Ball.VY *= scale
+			scale := speedCap / ball.Speed()
+			ball.VX *= scale
+			ball.VY *= scale
+		}
+		gs.recordHit(p, ball, save)
+
+		// Multi-ball: every N hits brings another ball into the rally
+		if gs.BallEvery > 0 {
+			gs.hitsSinceBall++
+			if gs.hitsSinceBall >= gs.BallEvery {
+				gs.hitsSinceBall = 0
+				gs.addBall(float64(gs.Width)/2, float64(gs.Height)/2, rand.Intn(2) == 0)
+			}
 		}
 
 		break // Only one collision per tick
 	}
 }
 
+// hitsColumnPaddle returns true if the ball is on a vertical paddle and moving toward it
+func (gs *GameState) hitsColumnPaddle(ball *Ball, p *Paddle) bool {
+	// Check if ball is at paddle column and within paddle
+	if int(ball.X) != p.Column || !p.ContainsY(ball.Y) {
+		return false
+	}
+
+	// Check direction - only collide if ball is moving toward paddle
+	if p.Team == protocol.TeamLeft {
+		return ball.VX <= 0
+	}
+	return ball.VX >= 0
+}
+
+// hitsRowPaddle returns true if the ball is on a horizontal paddle and moving toward it
+func (gs *GameState) hitsRowPaddle(ball *Ball, p *Paddle) bool {
+	if int(ball.Y) != p.Column || !p.ContainsY(ball.X) {
+		return false
+	}
+	if p.Team == protocol.TeamTop {
+		return ball.VY <= 0
+	}
+	return ball.VY >= 0
+}
+
 // countPlayersOnSide counts players on a team
 func (gs *GameState) countPlayersOnSide(team protocol.Team) int {
 	count := 0
@@ -276,57 +443,115 @@ func (gs *GameState) countPlayersOnSide(team protocol.Team) int {
 
 // CheckScore checks if ball has scored and updates state
 func (gs *GameState) CheckScore() {
+	// Extra balls score and leave the court without stopping play
+	setOver := false
+	kept := gs.ExtraBalls[:0]
+	for _, ball := range gs.ExtraBalls {
+		scorer, scored := gs.scoreBall(ball)
+		if !scored {
+			kept = append(kept, ball)
+			continue
+		}
+		if gs.checkSetOver() {
+			setOver = true
+			gs.LastScorer = scorer
+		}
+	}
+	gs.ExtraBalls = kept
+
+	scorer, scored := gs.scoreBall(gs.Ball)
+	if scored && gs.checkSetOver() {
+		setOver = true
+		gs.LastScorer = scorer
+	}
+
+	// Winning a set ends the rally, whatever else is still live
+	if setOver {
+		gs.startPause()
+		return
+	}
+
+	if !scored {
+		return
+	}
+
+	// While other balls are live, one of them takes over as the main ball
+	if len(gs.ExtraBalls) > 0 {
+		gs.Ball = gs.ExtraBalls[0]
+		gs.ExtraBalls = gs.ExtraBalls[1:]
+		return
+	}
+
+	// The last ball scoring ends the rally
+	gs.LastScorer = scorer
+	gs.startPause()
+}
+
+// scoreBall awards a point if the ball crossed a goal line
+func (gs *GameState) scoreBall(ball *Ball) (protocol.Team, bool) {
+	if gs.FourWay {
+		conceding, crossed := gs.concedingTeam(ball)
+		if !crossed {
+			return protocol.TeamLeft, false
+		}
+		gs.recordGoal(conceding, ball)
+		return gs.scoreFourWay(conceding), true
+	}
+
 	// Ball past left edge - right team scores
-	if gs.Ball.X < 0 {
+	if ball.X < 0 {
+		gs.recordGoal(protocol.TeamLeft, ball)
 		gs.RightScore++
-		gs.LastScorer = protocol.TeamRight
-		gs.startPause()
+		return protocol.TeamRight, true
 	}
 
 	// Ball past right edge - left team scores
-	if gs.Ball.X > float64(gs.Width) {
+	if ball.X > float64(gs.Width) {
+		gs.recordGoal(protocol.TeamRight, ball)
 		gs.LeftScore++
-		gs.LastScorer = protocol.TeamLeft
-		gs.startPause()
+		return protocol.TeamLeft, true
 	}
+
+	return protocol.TeamLeft, false
 }
 
 // startPause begins the post-score pause
 func (gs *GameState) startPause() {
 	gs.Paused = true
 	gs.PauseTicksLeft = TickRate // 1 second pause before serve screen
+	gs.ExtraBalls = nil
 }
 
 // Serve launches the ball - called when serving team presses Enter
 func (gs *GameState) Serve(playerID int) bool {
-	if !gs.WaitingForServe {
+	if !gs.WaitingForServe || gs.PausedBy != 0 {
 		return false
 	}
 
-	// Check if player is on serving team
+	// Check if player is on serving team, and their turn in a rotation
 	paddle := gs.GetPaddle(playerID)
 	if paddle == nil || paddle.Team != gs.ServingTeam {
 		return false
 	}
+	if gs.ServingPlayer != 0 && paddle.ID != gs.ServingPlayer {
+		return false
+	}
 
-	// Launch the ball toward the other team
-	launchRight := gs.ServingTeam == protocol.TeamLeft
-	gs.Ball.Reset(float64(gs.Width)/2, float64(gs.Height)/2, launchRight)
-	gs.WaitingForServe = false
+	// Launch the ball away from the serving team, aimed by the paddle
+	gs.serveFrom(paddle)
 	return true
 }
 
 // IsGameOver returns true if either team has won
 func (gs *GameState) IsGameOver() bool {
-	return gs.LeftScore >= gs.PointsToWin || gs.RightScore >= gs.PointsToWin
+	_, _, over := gs.MatchResult()
+	return over
 }
 
 // GetWinner returns the winning team
 func (gs *GameState) GetWinner() protocol.Team {
-	if gs.LeftScore >= gs.PointsToWin {
-		return protocol.TeamLeft
-	}
-	return protocol.TeamRight
+	winner, _, _ := gs.MatchResult()
+	return winner
 }
 
 // ToProtocolState converts to network-serializable state
@@ -334,23 +559,65 @@ func (gs *GameState) ToProtocolState() protocol.GameState {
 	paddles := make([]protocol.PaddleState, len(gs.Paddles))
 	for i, p := range gs.Paddles {
 		paddles[i] = protocol.PaddleState{
-			ID:     fmt.Sprintf("%d", p.ID),
-			Team:   p.Team,
-			Column: p.Column,
-			Y:      p.Y,
-			Height: p.Height,
-			Color:  p.Color,
+			ID:         fmt.Sprintf("%d", p.ID),
+			Team:       p.Team,
+			Column:     p.Column,
+			Y:          p.Y,
+			Height:     p.Height,
+			Color:      p.Color,
+			Horizontal: p.Horizontal,
+			Name:       gs.PlayerName(p.ID),
+		}
+	}
+
+	extraBalls := make([]protocol.BallState, len(gs.ExtraBalls))
+	for i, b := range gs.ExtraBalls {
+		extraBalls[i] = protocol.BallState{X: b.X, Y: b.Y, VX: b.VX, VY: b.VY, Spin: b.Spin}
+	}
+
+	powerUps := make([]protocol.PowerUpState, len(gs.PowerUps))
+	for i, item := range gs.PowerUps {
+		powerUps[i] = protocol.PowerUpState{ID: item.ID, Kind: item.Kind, X: item.X, Y: item.Y}
+	}
+
+	effects := make([]protocol.EffectState, len(gs.Effects))
+	for i, effect := range gs.Effects {
+		effects[i] = protocol.EffectState{
+			Kind:        effect.Kind,
+			Team:        effect.Team,
+			SecondsLeft: (effect.TicksLeft + TickRate - 1) / TickRate,
 		}
 	}
 
 	return protocol.GameState{
-		Tick:        gs.Tick,
-		Ball:        protocol.BallState{X: gs.Ball.X, Y: gs.Ball.Y, VX: gs.Ball.VX, VY: gs.Ball.VY},
-		Paddles:     paddles,
-		LeftScore:   gs.LeftScore,
-		RightScore:  gs.RightScore,
-		CourtWidth:  gs.Width,
-		CourtHeight: gs.Height,
-		PointsToWin: gs.PointsToWin,
+		Tick:              gs.Tick,
+		Ball:              protocol.BallState{X: gs.Ball.X, Y: gs.Ball.Y, VX: gs.Ball.VX, VY: gs.Ball.VY, Spin: gs.Ball.Spin},
+		ExtraBalls:        extraBalls,
+		Paddles:           paddles,
+		LeftScore:         gs.LeftScore,
+		RightScore:        gs.RightScore,
+		CourtWidth:        gs.Width,
+		CourtHeight:       gs.Height,
+		PointsToWin:       gs.PointsToWin,
+		PowerUps:          powerUps,
+		Effects:           effects,
+		PowerUpsCollected: gs.PowerUpsCollected,
+		ShieldBlocks:      gs.ShieldBlocks,
+		Match:             gs.MatchInfo(),
+		Court:             gs.CourtLayout(),
+		Sides:             gs.SideStates(),
+	}
+}
+
+// MatchInfo returns the set score and clock for the scoreboard
+func (gs *GameState) MatchInfo() protocol.MatchInfo {
+	return protocol.MatchInfo{
+		WinByTwo:  gs.Format.WinByTwo,
+		Sets:      gs.Format.Sets,
+		LeftSets:  gs.LeftSets,
+		RightSets: gs.RightSets,
+		TimeLimit: gs.Format.TimeLimit > 0,
+		TimeLeft:  (gs.TimeLeft() + TickRate - 1) / TickRate,
+		Overtime:  gs.Overtime,
 	}
 }
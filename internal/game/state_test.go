@@ -487,3 +487,137 @@ func TestGameState_PauseAfterScore(t *testing.T) {
 		t.Errorf("expected game to unpause when PauseTicksLeft reaches 0")
 	}
 }
+
+func TestGameState_MultiBallServe(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.BallCount = 3
+	gs.AddPlayer(1, "P1")
+	gs.AddPlayer(2, "P2")
+	gs.AssignTeams()
+
+	if len(gs.ExtraBalls) != 2 {
+		t.Fatalf("expected 2 extra balls after launch, got %d", len(gs.ExtraBalls))
+	}
+
+	// Serving puts the full set back in play
+	gs.ExtraBalls = nil
+	gs.WaitingForServe = true
+	gs.ServingTeam = gs.GetPaddle(1).Team
+	if !gs.Serve(1) {
+		t.Fatal("expected serve to succeed")
+	}
+	if len(gs.ExtraBalls) != 2 {
+		t.Errorf("expected 2 extra balls after serve, got %d", len(gs.ExtraBalls))
+	}
+	for _, ball := range gs.balls() {
+		if ball.Speed() == 0 {
+			t.Errorf("expected every ball to be launched")
+		}
+	}
+}
+
+func TestGameState_BallEveryHits(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.BallEvery = 2
+	gs.AddPlayer(1, "P1")
+	gs.AddPlayer(2, "P2")
+	gs.AssignTeams()
+
+	paddle := gs.GetPaddle(1)
+	hit := func() {
+		gs.Ball.X = float64(paddle.Column) + 0.5
+		gs.Ball.Y = paddle.Y
+		if paddle.Team == protocol.TeamLeft {
+			gs.Ball.VX = -0.5
+		} else {
+			gs.Ball.VX = 0.5
+		}
+		gs.checkPaddleCollisions(gs.Ball)
+	}
+
+	hit()
+	if len(gs.ExtraBalls) != 0 {
+		t.Fatalf("expected no extra ball after 1 hit, got %d", len(gs.ExtraBalls))
+	}
+	hit()
+	if len(gs.ExtraBalls) != 1 {
+		t.Errorf("expected an extra ball after 2 hits, got %d", len(gs.ExtraBalls))
+	}
+}
+
+func TestGameState_MultiBallGoalKeepsPlaying(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.AddPlayer(1, "P1")
+	gs.AddPlayer(2, "P2")
+	gs.AssignTeams()
+
+	other := NewBall(40, 12)
+	other.VX = 0.5
+	gs.ExtraBalls = []*Ball{other}
+
+	// Main ball scores while another is live
+	gs.Ball.X = -1
+	gs.CheckScore()
+
+	if gs.RightScore != 1 {
+		t.Errorf("expected RightScore=1, got %d", gs.RightScore)
+	}
+	if gs.Paused {
+		t.Errorf("expected play to continue while a ball is live")
+	}
+	if gs.Ball != other {
+		t.Errorf("expected the live ball to become the main ball")
+	}
+	if len(gs.ExtraBalls) != 0 {
+		t.Errorf("expected no extra balls left, got %d", len(gs.ExtraBalls))
+	}
+
+	// Last ball scoring pauses for the serve
+	gs.Ball.X = float64(gs.Width) + 1
+	gs.CheckScore()
+
+	if gs.LeftScore != 1 {
+		t.Errorf("expected LeftScore=1, got %d", gs.LeftScore)
+	}
+	if !gs.Paused {
+		t.Errorf("expected pause after the last ball scores")
+	}
+	if gs.LastScorer != protocol.TeamLeft {
+		t.Errorf("expected LastScorer=TeamLeft")
+	}
+}
+
+func TestGameState_AddBallLimit(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+
+	for i := 0; i < MaxBallsOnCourt*2; i++ {
+		gs.addBall(40, 12, true)
+	}
+
+	if len(gs.balls()) != MaxBallsOnCourt {
+		t.Errorf("expected at most %d balls, got %d", MaxBallsOnCourt, len(gs.balls()))
+	}
+}
+
+func TestGameState_MovingPaddleSpinsBall(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.AddPlayer(1, "Alice")
+	gs.AddPlayer(2, "Bob")
+	gs.AssignTeamsByPreference(map[int]protocol.TeamPreference{1: protocol.PreferLeft, 2: protocol.PreferRight})
+
+	left := gs.GetPaddle(1)
+	left.Velocity = 1.0
+	gs.Ball.X = float64(left.Column) + 0.5
+	gs.Ball.Y = left.Y
+	gs.Ball.VX = -0.5
+	gs.Ball.VY = 0
+
+	gs.checkPaddleCollisions(gs.Ball)
+
+	if gs.Ball.Spin <= 0 {
+		t.Errorf("expected a paddle moving down to spin the ball down, got %f", gs.Ball.Spin)
+	}
+	if state := gs.ToProtocolState(); state.Ball.Spin != gs.Ball.Spin {
+		t.Errorf("expected spin %f in protocol state, got %f", gs.Ball.Spin, state.Ball.Spin)
+	}
+}
@@ -0,0 +1,159 @@
+package game
+
+import (
+	"math"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// PlayerStats counts what one player did in a match
+type PlayerStats struct {
+	Hits     int
+	Saves    int     // Hits that kept out a ball the player had to reach for
+	Goals    int     // Last touch before a goal against another team
+	OwnGoals int     // Last touch before a goal against the player's own team
+	Conceded int     // Goals let in as the closest defender
+	TopSpeed float64 // Fastest ball the player hit, in cells per tick
+}
+
+// MatchStats collects player and rally statistics for a match
+type MatchStats struct {
+	Players      map[int]*PlayerStats
+	LongestRally int     // Most paddle hits between a serve and a goal
+	TopSpeed     float64 // Fastest ball of the match, in cells per tick
+	rally        int
+}
+
+// player returns the stats of a player, creating them on first use
+func (ms *MatchStats) player(id int) *PlayerStats {
+	if ms.Players == nil {
+		ms.Players = make(map[int]*PlayerStats)
+	}
+	stats, ok := ms.Players[id]
+	if !ok {
+		stats = &PlayerStats{}
+		ms.Players[id] = stats
+	}
+	return stats
+}
+
+// recordHit counts a paddle hit. save tells whether the ball was going in.
+func (gs *GameState) recordHit(p *Paddle, ball *Ball, save bool) {
+	stats := gs.Stats.player(p.ID)
+	stats.Hits++
+	if save {
+		stats.Saves++
+	}
+	speed := ball.Speed()
+	stats.TopSpeed = math.Max(stats.TopSpeed, speed)
+	gs.Stats.TopSpeed = math.Max(gs.Stats.TopSpeed, speed)
+
+	ball.LastTouch = p.ID
+	gs.Stats.rally++
+	gs.Stats.LongestRally = max(gs.Stats.LongestRally, gs.Stats.rally)
+}
+
+// recordGoal credits the last touch of a ball that crossed a team's goal
+// line, and charges the defender closest to where it went in
+func (gs *GameState) recordGoal(conceding protocol.Team, ball *Ball) {
+	if toucher := gs.GetPaddle(ball.LastTouch); toucher != nil {
+		if toucher.Team == conceding {
+			gs.Stats.player(toucher.ID).OwnGoals++
+		} else {
+			gs.Stats.player(toucher.ID).Goals++
+		}
+	}
+
+	var defender *Paddle
+	closest := math.Inf(1)
+	for _, p := range gs.Paddles {
+		if p.Team != conceding {
+			continue
+		}
+		along := ball.Y
+		if p.Horizontal {
+			along = ball.X
+		}
+		if d := math.Abs(p.Y - along); d < closest {
+			defender, closest = p, d
+		}
+	}
+	if defender != nil {
+		gs.Stats.player(defender.ID).Conceded++
+	}
+}
+
+// startRally resets the rally length on a serve
+func (gs *GameState) startRally() {
+	gs.Stats.rally = 0
+}
+
+// saveEdge is how far from the middle of a paddle a hit counts as a stretch,
+// as a share of half its height
+const saveEdge = 0.75
+
+// isSave returns true if a hit keeps out a ball that was going in and that
+// the paddle had to reach for: it was moving, or the ball caught its edge. A
+// paddle that was already in the way just hits the ball back.
+func (gs *GameState) isSave(ball *Ball, p *Paddle) bool {
+	if !gs.wouldScore(ball, p.Team) {
+		return false
+	}
+	if p.Velocity != 0 {
+		return true
+	}
+	along := ball.Y
+	if p.Horizontal {
+		along = ball.X
+	}
+	return math.Abs(along-p.Y) > float64(p.Height)/2*saveEdge
+}
+
+// wouldScore returns true if the ball, left alone, would cross the team's
+// goal line into the goal. Map obstacles are ignored.
+func (gs *GameState) wouldScore(ball *Ball, team protocol.Team) bool {
+	switch team {
+	case protocol.TeamLeft, protocol.TeamRight:
+		column := 0
+		if team == protocol.TeamRight {
+			column = gs.Width
+		}
+		y, _, ok := gs.predictArrival(ball, column)
+		if !ok {
+			return false
+		}
+		return gs.Court == nil || (y >= gs.Court.GoalTop && y <= gs.Court.GoalBottom)
+	case protocol.TeamTop:
+		_, _, ok := gs.predictRowArrival(ball, 0)
+		return ok
+	}
+	_, _, ok := gs.predictRowArrival(ball, gs.Height)
+	return ok
+}
+
+// PlayerStatsList returns every player's stats for the game over screen, in
+// join order
+func (gs *GameState) PlayerStatsList() []protocol.PlayerStats {
+	list := make([]protocol.PlayerStats, 0, len(gs.Players))
+	for _, info := range gs.Players {
+		paddle := gs.GetPaddle(info.ID)
+		if paddle == nil {
+			continue
+		}
+		stats, ok := gs.Stats.Players[info.ID]
+		if !ok {
+			stats = &PlayerStats{}
+		}
+		list = append(list, protocol.PlayerStats{
+			Name:     info.Name,
+			Team:     paddle.Team,
+			Hits:     stats.Hits,
+			Saves:    stats.Saves,
+			Goals:    stats.Goals,
+			OwnGoals: stats.OwnGoals,
+			Conceded: stats.Conceded,
+			TopSpeed: stats.TopSpeed * TickRate,
+		})
+	}
+	return list
+}
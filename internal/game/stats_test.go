@@ -0,0 +1,120 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// hitWith sends the ball into a column paddle, along VY
+func hitWith(gs *GameState, p *Paddle, vy float64) {
+	gs.Ball.X = float64(p.Column) + 0.5
+	gs.Ball.Y = p.Y
+	gs.Ball.VX = 0.5
+	if p.Team == protocol.TeamLeft {
+		gs.Ball.VX = -0.5
+	}
+	gs.Ball.VY = vy
+	gs.checkPaddleCollisions(gs.Ball)
+}
+
+func TestStats_HitsAndSaves(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	left := gs.GetPaddle(1)
+
+	// Straight at a paddle that is already in the way: just a hit
+	hitWith(gs, left, 0)
+	// At an angle, off the walls and into the goal, with the paddle moving
+	// across to reach it
+	left.Velocity = 0.5
+	hitWith(gs, left, 0.2)
+	left.Velocity = 0
+	// Caught on the edge of a paddle that stood still
+	gs.Ball.X = float64(left.Column) + 0.5
+	gs.Ball.Y = left.Y + float64(left.Height)/2 - 0.1
+	gs.Ball.VX, gs.Ball.VY = -0.5, 0
+	gs.checkPaddleCollisions(gs.Ball)
+
+	stats := gs.Stats.Players[1]
+	if stats == nil || stats.Hits != 3 {
+		t.Fatalf("expected 3 hits, got %+v", stats)
+	}
+	if stats.Saves != 2 {
+		t.Errorf("expected the moving and edge hits to be saves, got %d", stats.Saves)
+	}
+	if stats.TopSpeed <= 0 || gs.Stats.TopSpeed < stats.TopSpeed {
+		t.Errorf("expected top speeds to be recorded, got %f and %f", stats.TopSpeed, gs.Stats.TopSpeed)
+	}
+}
+
+func TestStats_SaveNeedsOpenGoal(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	gs.SetMap(&Map{Name: "narrow", GoalSize: 0.2})
+	left := gs.GetPaddle(1)
+
+	// Heading for the wall beside a narrow goal mouth
+	left.Y = 3
+	left.Velocity = 0.5
+	hitWith(gs, left, 0)
+	if gs.Stats.Players[1].Saves != 0 {
+		t.Error("expected no save for a ball going into the goal wall")
+	}
+}
+
+func TestStats_GoalsAndConceded(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	left, right := gs.GetPaddle(1), gs.GetPaddle(2)
+
+	// Left hits, the ball goes past the right paddle
+	hitWith(gs, left, 0)
+	gs.Ball.X = float64(gs.Width) + 1
+	gs.CheckScore()
+
+	if gs.Stats.Players[left.ID].Goals != 1 {
+		t.Errorf("expected the last touch to get the goal, got %+v", gs.Stats.Players[left.ID])
+	}
+	if gs.Stats.Players[right.ID].Conceded != 1 {
+		t.Errorf("expected the defender to concede, got %+v", gs.Stats.Players[right.ID])
+	}
+
+	// Own goal: right hits the ball back into its own goal
+	gs.launchFrom(protocol.TeamLeft)
+	hitWith(gs, right, 0)
+	gs.Ball.X = float64(gs.Width) + 1
+	gs.CheckScore()
+	if gs.Stats.Players[right.ID].OwnGoals != 1 {
+		t.Errorf("expected an own goal, got %+v", gs.Stats.Players[right.ID])
+	}
+}
+
+func TestStats_LongestRally(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	left, right := gs.GetPaddle(1), gs.GetPaddle(2)
+
+	for i := 0; i < 3; i++ {
+		hitWith(gs, left, 0)
+		hitWith(gs, right, 0)
+	}
+	gs.launchFrom(protocol.TeamLeft)
+	hitWith(gs, left, 0)
+
+	if gs.Stats.LongestRally != 6 {
+		t.Errorf("expected a longest rally of 6 hits, got %d", gs.Stats.LongestRally)
+	}
+}
+
+func TestStats_PlayerStatsList(t *testing.T) {
+	gs := newServeGame(ServeStandard, 2)
+	hitWith(gs, gs.GetPaddle(2), 0)
+
+	list := gs.PlayerStatsList()
+	if len(list) != 2 {
+		t.Fatalf("expected stats for 2 players, got %d", len(list))
+	}
+	if list[0].Hits != 0 || list[1].Hits != 1 {
+		t.Errorf("expected hits 0 and 1 in join order, got %d and %d", list[0].Hits, list[1].Hits)
+	}
+	if list[1].TopSpeed <= 1 {
+		t.Errorf("expected top speed in cells per second, got %f", list[1].TopSpeed)
+	}
+}
@@ -0,0 +1,189 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// ResolveTeams splits players into teams. Players who picked a side get it,
+// the rest are shuffled into whichever team is smaller.
+func ResolveTeams(ids []int, prefs map[int]protocol.TeamPreference) (left, right []int) {
+	var random []int
+	for _, id := range ids {
+		switch prefs[id] {
+		case protocol.PreferLeft:
+			left = append(left, id)
+		case protocol.PreferRight:
+			right = append(right, id)
+		default:
+			random = append(random, id)
+		}
+	}
+
+	rand.Shuffle(len(random), func(i, j int) {
+		random[i], random[j] = random[j], random[i]
+	})
+
+	// Ties go right, so an odd player out ends up on the right team
+	for _, id := range random {
+		if len(left) < len(right) {
+			left = append(left, id)
+		} else {
+			right = append(right, id)
+		}
+	}
+	return left, right
+}
+
+// BalanceTeams evens out team sizes and returns the side every player ends
+// up on. Players without a preference are moved first, then the ones who
+// joined last.
+func BalanceTeams(ids []int, prefs map[int]protocol.TeamPreference) map[int]protocol.TeamPreference {
+	left, right := ResolveTeams(ids, prefs)
+
+	for len(left)-len(right) > 1 {
+		left, right = movePlayer(left, right, prefs)
+	}
+	for len(right)-len(left) > 1 {
+		right, left = movePlayer(right, left, prefs)
+	}
+
+	balanced := make(map[int]protocol.TeamPreference, len(ids))
+	for _, id := range left {
+		balanced[id] = protocol.PreferLeft
+	}
+	for _, id := range right {
+		balanced[id] = protocol.PreferRight
+	}
+	return balanced
+}
+
+// BalanceByRating splits players into even-sized teams with ratings as close
+// as possible, and returns the side every player ends up on
+func BalanceByRating(ids []int, ratings map[int]float64) map[int]protocol.TeamPreference {
+	sorted := append([]int(nil), ids...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return ratings[sorted[i]] > ratings[sorted[j]]
+	})
+
+	// Deal the strongest players first, each to the weaker team with room left
+	leftRoom, rightRoom := len(ids)/2, len(ids)-len(ids)/2
+	var left, right []int
+	leftTotal, rightTotal := 0.0, 0.0
+	for _, id := range sorted {
+		if rightRoom == 0 || (leftRoom > 0 && leftTotal <= rightTotal) {
+			left = append(left, id)
+			leftTotal += ratings[id]
+			leftRoom--
+		} else {
+			right = append(right, id)
+			rightTotal += ratings[id]
+			rightRoom--
+		}
+	}
+
+	// Then swap pairs while it brings the team averages closer
+	gap := func() float64 {
+		return math.Abs(averageRating(left, ratings) - averageRating(right, ratings))
+	}
+	for improved := true; improved; {
+		improved = false
+		for i := range left {
+			for j := range right {
+				before := gap()
+				left[i], right[j] = right[j], left[i]
+				if gap() < before-1e-9 {
+					improved = true
+				} else {
+					left[i], right[j] = right[j], left[i]
+				}
+			}
+		}
+	}
+
+	balanced := make(map[int]protocol.TeamPreference, len(ids))
+	for _, id := range left {
+		balanced[id] = protocol.PreferLeft
+	}
+	for _, id := range right {
+		balanced[id] = protocol.PreferRight
+	}
+	return balanced
+}
+
+// averageRating returns the mean rating of a team
+func averageRating(ids []int, ratings map[int]float64) float64 {
+	if len(ids) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, id := range ids {
+		total += ratings[id]
+	}
+	return total / float64(len(ids))
+}
+
+// movePlayer moves one player from one team to the other
+func movePlayer(from, to []int, prefs map[int]protocol.TeamPreference) ([]int, []int) {
+	pick := len(from) - 1
+	for i := len(from) - 1; i >= 0; i-- {
+		if prefs[from[i]] == protocol.PreferRandom {
+			pick = i
+			break
+		}
+	}
+
+	to = append(to, from[pick])
+	from = append(from[:pick:pick], from[pick+1:]...)
+	return from, to
+}
+
+// PreferenceFor returns the preference that puts a player on the given team
+func PreferenceFor(team protocol.Team) protocol.TeamPreference {
+	switch team {
+	case protocol.TeamLeft:
+		return protocol.PreferLeft
+	case protocol.TeamTop:
+		return protocol.PreferTop
+	case protocol.TeamBottom:
+		return protocol.PreferBottom
+	}
+	return protocol.PreferRight
+}
+
+// AssignTeamsByPreference assigns players to the teams they picked and
+// positions paddles. Players missing from prefs are placed randomly.
+func (gs *GameState) AssignTeamsByPreference(prefs map[int]protocol.TeamPreference) {
+	if len(gs.Paddles) == 0 {
+		return
+	}
+	if gs.FourWay {
+		gs.assignFourTeams(prefs)
+		return
+	}
+
+	ids := make([]int, len(gs.Paddles))
+	for i, p := range gs.Paddles {
+		ids[i] = p.ID
+	}
+	leftIDs, rightIDs := ResolveTeams(ids, prefs)
+
+	leftTeam := make([]*Paddle, 0, len(leftIDs))
+	for _, id := range leftIDs {
+		leftTeam = append(leftTeam, gs.GetPaddle(id))
+	}
+	rightTeam := make([]*Paddle, 0, len(rightIDs))
+	for _, id := range rightIDs {
+		rightTeam = append(rightTeam, gs.GetPaddle(id))
+	}
+
+	// Calculate paddle height per team - fewer players = bigger paddles
+	gs.assignTeamPaddles(leftTeam, protocol.TeamLeft, gs.CalculatePaddleHeight(len(leftTeam)))
+	gs.assignTeamPaddles(rightTeam, protocol.TeamRight, gs.CalculatePaddleHeight(len(rightTeam)))
+
+	// Initialize ball with velocity (launch toward random team)
+	gs.launchBall(rand.Intn(2) == 0)
+}
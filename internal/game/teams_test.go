@@ -0,0 +1,159 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+func TestResolveTeams(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5}
+	prefs := map[int]protocol.TeamPreference{
+		1: protocol.PreferLeft,
+		2: protocol.PreferLeft,
+		3: protocol.PreferLeft,
+	}
+
+	left, right := ResolveTeams(ids, prefs)
+
+	// Picks are kept, random players fill the smaller team
+	if len(left) != 3 || len(right) != 2 {
+		t.Fatalf("expected 3 left and 2 right, got %d and %d", len(left), len(right))
+	}
+	for _, id := range right {
+		if id != 4 && id != 5 {
+			t.Errorf("expected only random players on the right, got %d", id)
+		}
+	}
+}
+
+func TestResolveTeams_AllRandom(t *testing.T) {
+	left, right := ResolveTeams([]int{1, 2, 3}, nil)
+
+	if len(left) != 1 || len(right) != 2 {
+		t.Errorf("expected 1 left and 2 right, got %d and %d", len(left), len(right))
+	}
+}
+
+func TestBalanceTeams(t *testing.T) {
+	ids := []int{1, 2, 3, 4}
+	prefs := map[int]protocol.TeamPreference{
+		1: protocol.PreferLeft,
+		2: protocol.PreferLeft,
+		3: protocol.PreferLeft,
+		4: protocol.PreferLeft,
+	}
+
+	balanced := BalanceTeams(ids, prefs)
+
+	leftCount := 0
+	for _, id := range ids {
+		switch balanced[id] {
+		case protocol.PreferLeft:
+			leftCount++
+		case protocol.PreferRight:
+		default:
+			t.Errorf("expected player %d to have a side, got %d", id, balanced[id])
+		}
+	}
+	if leftCount != 2 {
+		t.Errorf("expected 2 left players after balancing, got %d", leftCount)
+	}
+
+	// The players who joined last are moved
+	if balanced[1] != protocol.PreferLeft || balanced[2] != protocol.PreferLeft {
+		t.Errorf("expected the first players to keep their side")
+	}
+}
+
+func TestBalanceTeams_MovesRandomFirst(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5}
+	prefs := map[int]protocol.TeamPreference{
+		1: protocol.PreferRight,
+		2: protocol.PreferRight,
+		3: protocol.PreferRight,
+		4: protocol.PreferRight,
+	}
+
+	balanced := BalanceTeams(ids, prefs)
+
+	// Player 5 had no preference, so it goes left before anyone is moved
+	if balanced[5] != protocol.PreferLeft {
+		t.Errorf("expected the random player to go left")
+	}
+	if balanced[1] != protocol.PreferRight {
+		t.Errorf("expected the first player to keep their side")
+	}
+}
+
+func TestGameState_AssignTeamsByPreference(t *testing.T) {
+	gs := NewGameState(80, 24, 10)
+	gs.AddPlayer(1, "P1")
+	gs.AddPlayer(2, "P2")
+	gs.AddPlayer(3, "P3")
+
+	gs.AssignTeamsByPreference(map[int]protocol.TeamPreference{
+		1: protocol.PreferRight,
+		2: protocol.PreferRight,
+		3: protocol.PreferLeft,
+	})
+
+	if gs.GetPaddle(1).Team != protocol.TeamRight || gs.GetPaddle(2).Team != protocol.TeamRight {
+		t.Errorf("expected players 1 and 2 on the right team")
+	}
+	left := gs.GetPaddle(3)
+	if left.Team != protocol.TeamLeft {
+		t.Fatalf("expected player 3 on the left team")
+	}
+
+	// A player alone on a team gets the biggest paddle
+	if left.Height != gs.CalculatePaddleHeight(1) {
+		t.Errorf("expected height %d, got %d", gs.CalculatePaddleHeight(1), left.Height)
+	}
+	if left.Column < 1 || left.Column > gs.Width/4 {
+		t.Errorf("left paddle column %d out of expected range", left.Column)
+	}
+}
+
+func TestPreferenceFor(t *testing.T) {
+	if PreferenceFor(protocol.TeamLeft) != protocol.PreferLeft {
+		t.Error("expected PreferLeft for TeamLeft")
+	}
+	if PreferenceFor(protocol.TeamRight) != protocol.PreferRight {
+		t.Error("expected PreferRight for TeamRight")
+	}
+}
+
+func TestBalanceByRating(t *testing.T) {
+	ids := []int{1, 2, 3, 4}
+	ratings := map[int]float64{
+		1: 1800,
+		2: 1700,
+		3: 1300,
+		4: 1200,
+	}
+
+	balanced := BalanceByRating(ids, ratings)
+
+	// The best split pairs the strongest with the weakest
+	if balanced[1] != balanced[4] || balanced[2] != balanced[3] || balanced[1] == balanced[2] {
+		t.Errorf("expected {1,4} vs {2,3}, got %v", balanced)
+	}
+}
+
+func TestBalanceByRating_OddPlayers(t *testing.T) {
+	ids := []int{1, 2, 3}
+	ratings := map[int]float64{1: 1500, 2: 1500, 3: 1500}
+
+	balanced := BalanceByRating(ids, ratings)
+
+	leftCount := 0
+	for _, id := range ids {
+		if balanced[id] == protocol.PreferLeft {
+			leftCount++
+		}
+	}
+	if leftCount != 1 {
+		t.Errorf("expected 1 left and 2 right, got %d left", leftCount)
+	}
+}
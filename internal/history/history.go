@@ -0,0 +1,189 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Player is one player of a recorded match
+type Player struct {
+	Name string `json:"name"`
+	Team string `json:"team"`
+	Bot  bool   `json:"bot,omitempty"`
+}
+
+// Match is the record of one finished match
+type Match struct {
+	Date     time.Time      `json:"date"`
+	Players  []Player       `json:"players"`
+	Winner   string         `json:"winner"`
+	Score    map[string]int `json:"score"`   // Goals per team
+	Seconds  int            `json:"seconds"` // Time played
+	Rules    string         `json:"rules"`
+	Map      string         `json:"map,omitempty"`
+	FourWay  bool           `json:"four_way,omitempty"`
+	Decision string         `json:"decision,omitempty"`
+}
+
+// Append adds a match to the end of the history file, one JSON object per
+// line, so a crash never loses the earlier matches
+func Append(path string, m Match) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to encode match: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// Load reads every match in the history file, oldest first, and counts the
+// lines it skipped because they aren't matches, like one cut short by a
+// crash. A missing file gives no matches.
+func Load(path string) ([]Match, int, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer f.Close()
+
+	var matches []Match
+	skipped := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var m Match
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			skipped++
+			continue
+		}
+		matches = append(matches, m)
+	}
+	if err := scanner.Err(); err != nil {
+		return matches, skipped, fmt.Errorf("failed to read history: %w", err)
+	}
+	return matches, skipped, nil
+}
+
+// Standing is a player's record over the history
+type Standing struct {
+	Name       string
+	Wins       int
+	Losses     int
+	Streak     int // Current run, positive for wins and negative for losses
+	BestStreak int // Most wins in a row
+}
+
+// Played returns how many matches the player finished
+func (s Standing) Played() int {
+	return s.Wins + s.Losses
+}
+
+// WinRate returns the share of matches won, from 0 to 1
+func (s Standing) WinRate() float64 {
+	if s.Played() == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Played())
+}
+
+// Leaderboard ranks the players of the given matches, oldest match first,
+// by wins, then win rate. Like ratings, only matches between people count,
+// and bots are left out.
+func Leaderboard(matches []Match) []Standing {
+	byName := make(map[string]*Standing)
+	for _, m := range matches {
+		if !m.betweenPeople() {
+			continue
+		}
+		for _, p := range m.Players {
+			if p.Bot {
+				continue
+			}
+			s, ok := byName[p.Name]
+			if !ok {
+				s = &Standing{Name: p.Name}
+				byName[p.Name] = s
+			}
+			s.record(p.Team == m.Winner)
+		}
+	}
+
+	standings := make([]Standing, 0, len(byName))
+	for _, s := range byName {
+		standings = append(standings, *s)
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if a.WinRate() != b.WinRate() {
+			return a.WinRate() > b.WinRate()
+		}
+		return a.Name < b.Name
+	})
+	return standings
+}
+
+// betweenPeople returns true if the winners and the losers each had at least
+// one person, so games against bots alone don't count
+func (m Match) betweenPeople() bool {
+	var winners, losers bool
+	for _, p := range m.Players {
+		switch {
+		case p.Bot:
+		case p.Team == m.Winner:
+			winners = true
+		default:
+			losers = true
+		}
+	}
+	return winners && losers
+}
+
+// StreakText formats a streak as W3 for wins in a row, L2 for losses
+func StreakText(streak int) string {
+	switch {
+	case streak > 0:
+		return fmt.Sprintf("W%d", streak)
+	case streak < 0:
+		return fmt.Sprintf("L%d", -streak)
+	}
+	return "-"
+}
+
+// record adds one result to the standing
+func (s *Standing) record(won bool) {
+	if won {
+		s.Wins++
+		s.Streak = max(s.Streak, 0) + 1
+		s.BestStreak = max(s.BestStreak, s.Streak)
+		return
+	}
+	s.Losses++
+	s.Streak = min(s.Streak, 0) - 1
+}
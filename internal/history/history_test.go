@@ -0,0 +1,106 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func match(winner string, players ...Player) Match {
+	return Match{Date: time.Now(), Winner: winner, Players: players, Rules: "classic"}
+}
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "history.jsonl")
+
+	matches, _, err := Load(path)
+	if err != nil || len(matches) != 0 {
+		t.Fatalf("expected no matches from a missing file, got %d (%v)", len(matches), err)
+	}
+
+	first := match("left", Player{Name: "Alice", Team: "left"}, Player{Name: "Bob", Team: "right"})
+	first.Score = map[string]int{"left": 10, "right": 7}
+	first.Seconds = 184
+	if err := Append(path, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Append(path, match("right", Player{Name: "Alice", Team: "left"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	matches, skipped, err := Load(path)
+	if err != nil || skipped != 0 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Score["left"] != 10 || matches[0].Seconds != 184 || len(matches[0].Players) != 2 {
+		t.Errorf("expected the first match back as written, got %+v", matches[0])
+	}
+	if matches[1].Winner != "right" {
+		t.Errorf("expected matches in order, got winner %q", matches[1].Winner)
+	}
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	if err := os.WriteFile(path, []byte("{\"winner\":\"left\"}\nnot json\n{\"winner\":\"right\"}\n{\"winn"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, skipped, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matches) != 2 || skipped != 2 {
+		t.Errorf("expected the 2 matches around the corrupt lines and 2 skipped, got %d and %d", len(matches), skipped)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	alice := Player{Name: "Alice", Team: "left"}
+	bob := Player{Name: "Bob", Team: "right"}
+	bot := Player{Name: "Bot 1", Team: "right", Bot: true}
+	teammate := Player{Name: "Bot 2", Team: "left", Bot: true}
+
+	matches := []Match{
+		match("left", alice, bob),
+		match("left", alice, teammate, bob),
+		match("right", alice, bob),
+		match("left", alice, bot),
+	}
+	standings := Leaderboard(matches)
+
+	if len(standings) != 2 {
+		t.Fatalf("expected bots to be left out, got %d standings", len(standings))
+	}
+
+	first, second := standings[0], standings[1]
+	if first.Name != "Alice" || first.Wins != 2 || first.Losses != 1 {
+		t.Errorf("expected Alice first with 2-1, not counting the win against a bot, got %+v", first)
+	}
+	if first.Streak != -1 || first.BestStreak != 2 {
+		t.Errorf("expected Alice on a 1 loss streak with a best of 2, got %+v", first)
+	}
+	if second.Name != "Bob" || second.Streak != 1 || second.WinRate() != 1.0/3 {
+		t.Errorf("expected Bob 1-2 on a 1 win streak, got %+v", second)
+	}
+}
+
+func TestStanding_LosingStreak(t *testing.T) {
+	var s Standing
+	s.record(true)
+	s.record(false)
+	s.record(false)
+	if s.Streak != -2 {
+		t.Errorf("expected a 2 loss streak, got %d", s.Streak)
+	}
+	if s.Played() != 3 {
+		t.Errorf("expected 3 played, got %d", s.Played())
+	}
+	if got := StreakText(s.Streak); got != "L2" {
+		t.Errorf("expected streak L2, got %q", got)
+	}
+}
@@ -8,17 +8,56 @@ import (
 type Direction int
 
 const (
-	DirNone Direction = 0
-	DirUp   Direction = 1
-	DirDown Direction = 2
+	DirNone  Direction = 0
+	DirUp    Direction = 1
+	DirDown  Direction = 2
+	DirLeft  Direction = 3
+	DirRight Direction = 4
 )
 
 // Team represents which side a player is on
 type Team int
 
 const (
-	TeamLeft  Team = 0
-	TeamRight Team = 1
+	TeamLeft   Team = 0
+	TeamRight  Team = 1
+	TeamTop    Team = 2 // Four-way mode only
+	TeamBottom Team = 3 // Four-way mode only
+)
+
+// TeamPreference is the side a player asks for in the lobby
+type TeamPreference int
+
+const (
+	PreferRandom TeamPreference = iota
+	PreferLeft
+	PreferRight
+	PreferTop    // Four-way mode only
+	PreferBottom // Four-way mode only
+)
+
+// PowerUpKind identifies a power-up item and its effect
+type PowerUpKind int
+
+const (
+	PowerUpGrow      PowerUpKind = iota // Bigger paddles for the collecting team
+	PowerUpShrink                       // Smaller paddles for the other team
+	PowerUpSpeed                        // Faster ball
+	PowerUpReverse                      // Reversed controls for the other team
+	PowerUpExtraBall                    // Adds another ball to the court
+	PowerUpShield                       // Temporary wall on the collecting team's goal line
+)
+
+// MatchDecision describes how a match was decided
+type MatchDecision int
+
+const (
+	DecidedByPoints       MatchDecision = iota // First to the target score
+	DecidedByTwoPointLead                      // Two-point lead after deuce
+	DecidedByTime                              // Ahead when the clock ran out
+	DecidedByOvertime                          // Sudden-death goal in overtime
+	DecidedBySets                              // Won the majority of sets
+	DecidedByLastStanding                      // Only team left in four-way mode
 )
 
 // MessageType identifies the type of network message
@@ -37,6 +76,10 @@ const (
 	MsgCountdown
 	MsgPauseState
 	MsgServe
+	MsgTeamChoice
+	MsgReshuffle
+	MsgPause  // Pause the match, or vote to resume it
+	MsgResize // The player's terminal changed size
 )
 
 // Message is the wrapper for all network messages
@@ -57,6 +100,17 @@ type JoinRequest struct {
 	TerminalHeight int
 }
 
+// Resize is sent by a client whose terminal changed size
+type Resize struct {
+	TerminalWidth  int
+	TerminalHeight int
+}
+
+// TeamChoice is sent by a player picking a side in the lobby
+type TeamChoice struct {
+	Preference TeamPreference
+}
+
 // JoinResponse is sent by the server in response to a join request
 type JoinResponse struct {
 	PlayerID string
@@ -66,48 +120,159 @@ type JoinResponse struct {
 
 // BallState represents the ball's position and velocity
 type BallState struct {
-	X  float64
-	Y  float64
-	VX float64
-	VY float64
+	X    float64
+	Y    float64
+	VX   float64
+	VY   float64
+	Spin float64 // Curve in radians per tick, 0 when not spinning
 }
 
-// PaddleState represents a paddle's state
+// PaddleState represents a paddle's state. Horizontal paddles guard the top
+// and bottom edges: Column is then their row, and Y and Height run along it.
 type PaddleState struct {
-	ID     string
-	Team   Team
-	Column int
-	Y      float64
-	Height int
-	Color  int
+	ID         string
+	Team       Team
+	Column     int
+	Y          float64
+	Height     int
+	Color      int
+	Horizontal bool
+	Name       string // Player name, for labels on the court
+}
+
+// PowerUpState represents a power-up item waiting on the court
+type PowerUpState struct {
+	ID   int
+	Kind PowerUpKind
+	X    float64
+	Y    float64
+}
+
+// EffectState represents an active power-up effect on a team
+type EffectState struct {
+	Kind        PowerUpKind
+	Team        Team
+	SecondsLeft int
+}
+
+// MatchInfo represents the match format, set score and clock
+type MatchInfo struct {
+	WinByTwo  bool
+	Sets      int // Best of N sets, 0 or 1 for a single game
+	LeftSets  int
+	RightSets int
+	TimeLimit bool
+	TimeLeft  int // Seconds left on the clock
+	Overtime  bool
+}
+
+// BlockState represents a rectangular map obstacle
+type BlockState struct {
+	X, Y, W, H float64
+}
+
+// BumperState represents a round map obstacle
+type BumperState struct {
+	X, Y, R float64
+}
+
+// CourtLayout represents the map obstacles and goal mouth, in court cells
+type CourtLayout struct {
+	Blocks     []BlockState
+	Bumpers    []BumperState
+	GoalTop    float64 // Goal mouth on both edges, the rest is wall
+	GoalBottom float64
+}
+
+// SideState represents one team's score in four-way mode
+type SideState struct {
+	Team     Team
+	Score    int
+	Conceded int
+	Out      bool // Conceded too many goals, its edge is now a wall
 }
 
 // GameState represents the complete game state
 type GameState struct {
-	Tick        int
-	Ball        BallState
-	Paddles     []PaddleState
-	LeftScore   int
-	RightScore  int
-	CourtWidth  int
-	CourtHeight int
-	PointsToWin int
+	Tick              int
+	Ball              BallState
+	ExtraBalls        []BallState
+	Paddles           []PaddleState
+	LeftScore         int
+	RightScore        int
+	CourtWidth        int
+	CourtHeight       int
+	PointsToWin       int
+	PowerUps          []PowerUpState
+	Effects           []EffectState
+	PowerUpsCollected int // Running count, used to trigger sounds
+	ShieldBlocks      int // Running count, used to trigger sounds
+	Match             MatchInfo
+	Court             CourtLayout
+	Sides             []SideState // Four-way mode only
 }
 
 // LobbyPlayer represents a player in the lobby
 type LobbyPlayer struct {
-	ID    string
-	Name  string
-	Color int
+	ID         string
+	Name       string
+	Color      int
+	IsBot      bool
+	Preference TeamPreference
+	Rating     int // Skill rating, 0 for bots
+	QueuePos   int // Place in the winner-stays-on line, 0 when on court
+	Entrant    int // Tournament team the host registered the player in, 0 for none
 }
 
 // LobbyState represents the lobby state
 type LobbyState struct {
-	Players     []LobbyPlayer
-	IsHost      bool
-	CanStart    bool
-	ServerAddrs []string
-	PointsToWin int
+	Players       []LobbyPlayer
+	IsHost        bool
+	CanStart      bool
+	NotReady      string // Why the host can't start yet, empty when only waiting for players
+	TeamsLocked   bool   // Only the host can move players between teams
+	ServerAddrs   []string
+	PointsToWin   int
+	BotDifficulty string
+	PowerUps      bool
+	Balls         int
+	BallEvery     int
+	WinByTwo      bool
+	TimeLimit     int // Seconds, 0 for no limit
+	Sets          int
+	MapName       string
+	FourWay       bool
+	Lateral       string // Lateral paddle movement mode, "off" when disabled
+	Rules         RulesInfo
+	ServeRule     string     // Who serves after a goal
+	ServeTime     int        // Seconds before an automatic serve, 0 to wait forever
+	Pauses        int        // Pauses each team may call per match
+	Standings     []Standing // Match history of the players in the lobby
+	Skipped       int        // Damaged match history lines left out of the standings
+	Tournament    string     // Bracket format, empty for normal matches
+	TeamSize      int        // Players per tournament entrant
+	King          int        // Players per side in winner-stays-on, 0 when off
+	CourtWidth    int        // Fixed court size, 0 when fitted to the smallest terminal
+	CourtHeight   int
+}
+
+// Standing is a player's record in the match history
+type Standing struct {
+	Name       string
+	Wins       int
+	Losses     int
+	Streak     int // Current run, positive for wins and negative for losses
+	BestStreak int
+}
+
+// RulesInfo summarizes the physics rules of the next match
+type RulesInfo struct {
+	Name           string
+	BallSpeed      float64
+	MaxBounceAngle int // Degrees
+	SpeedIncrement float64
+	SpeedCap       float64
+	PaddleHeight   int
 }
 
 // GameOverState represents the end of game state
@@ -115,21 +280,76 @@ type GameOverState struct {
 	WinningTeam Team
 	LeftScore   int
 	RightScore  int
+	Decision    MatchDecision
+	Match       MatchInfo
+	Ratings     []RatingChange
+	Sides       []SideState // Four-way mode only
+
+	// Match statistics
+	Stats        []PlayerStats
+	LongestRally int     // Most paddle hits between a serve and a goal
+	TopSpeed     float64 // Fastest ball of the match, in cells per second
+
+	SaveErrors []string // Why the server couldn't save the results to disk
+}
+
+// PlayerStats is what one player did in a match
+type PlayerStats struct {
+	Name     string
+	Team     Team
+	Hits     int
+	Saves    int     // Hits that kept out a ball they had to reach for
+	Goals    int     // Last touch before a goal against another team
+	OwnGoals int     // Last touch before a goal against their own team
+	Conceded int     // Goals let in as the closest defender
+	TopSpeed float64 // Fastest ball they hit, in cells per second
+}
+
+// RatingChange represents how a player's skill rating moved after a match
+type RatingChange struct {
+	Name   string
+	Before int
+	After  int
 }
 
 // RematchPlayer represents a player in the rematch screen
 type RematchPlayer struct {
-	ID    string
-	Name  string
-	Color int
-	Ready bool
+	ID          string
+	Name        string
+	Color       int
+	Ready       bool
+	IsBot       bool
+	Team        Team // Side in the last match
+	Rating      int  // Skill rating, 0 for bots
+	RatingDelta int  // How the last match moved the rating
+	QueuePos    int  // Place in the winner-stays-on line, 0 when on court
 }
 
 // RematchState represents the rematch screen state
 type RematchState struct {
-	Players  []RematchPlayer
-	IsHost   bool
-	AllReady bool
+	Players   []RematchPlayer
+	IsHost    bool
+	AllReady  bool
+	Reshuffle bool          // Someone asked for new random teams
+	Bracket   *BracketState // Tournament progress, nil outside a tournament
+	King      int           // Players per side in winner-stays-on, 0 when off
+}
+
+// BracketMatch is one match of a tournament bracket
+type BracketMatch struct {
+	Side     string // "winners", "losers" or "final"
+	Round    int
+	Names    [2]string // Empty while waiting for an earlier match
+	Winner   int       // Slot of the winner, -1 until played
+	Walkover bool      // Decided by a bye or a no-show
+	Next     bool      // The match about to be played
+}
+
+// BracketState is the progress of a tournament
+type BracketState struct {
+	Format   string // "single" or "double" elimination
+	Matches  []BracketMatch
+	Champion string // Set once the tournament is over
 }
 
 // Countdown represents the countdown before game starts
@@ -139,27 +359,56 @@ type Countdown struct {
 
 // PauseState represents the pause state after a point is scored
 type PauseState struct {
-	SecondsLeft     int
-	LeftScore       int
-	RightScore      int
-	LastScorer      Team
-	WaitingForServe bool
-	ServingTeam     Team
+	SecondsLeft      int
+	LeftScore        int
+	RightScore       int
+	LastScorer       Team
+	WaitingForServe  bool
+	ServingTeam      Team
+	ServerName       string // Player whose turn it is to serve, empty for anyone on the team
+	ServeSecondsLeft int    // Until the ball is served automatically, 0 without a serve clock
+	SetEnded         bool   // The last goal won a set
+	Match            MatchInfo
+	Sides            []SideState // Four-way mode only
+	Game             *GameState  // The court while waiting for serve or paused by a player
+
+	// Pause called by a player
+	PausedBy          string // Empty for the pause after a goal
+	PausedTeam        Team
+	PausesLeft        int // Pauses the pausing team has left
+	ResumeVotes       int
+	VotesNeeded       int
+	ResumeSecondsLeft int // Countdown to play once the vote passed, 0 while voting
 }
 
 func init() {
 	// Register all payload types with gob for network serialization
 	gob.Register(PlayerInput{})
 	gob.Register(JoinRequest{})
+	gob.Register(TeamChoice{})
+	gob.Register(Resize{})
 	gob.Register(JoinResponse{})
 	gob.Register(BallState{})
 	gob.Register(PaddleState{})
+	gob.Register(PowerUpState{})
+	gob.Register(EffectState{})
+	gob.Register(MatchInfo{})
+	gob.Register(SideState{})
+	gob.Register(RulesInfo{})
+	gob.Register(PlayerStats{})
+	gob.Register(Standing{})
+	gob.Register(BlockState{})
+	gob.Register(BumperState{})
+	gob.Register(CourtLayout{})
 	gob.Register(GameState{})
 	gob.Register(LobbyPlayer{})
 	gob.Register(LobbyState{})
 	gob.Register(GameOverState{})
+	gob.Register(RatingChange{})
 	gob.Register(RematchPlayer{})
 	gob.Register(RematchState{})
+	gob.Register(BracketMatch{})
+	gob.Register(BracketState{})
 	gob.Register(Countdown{})
 	gob.Register(PauseState{})
 }
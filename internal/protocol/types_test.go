@@ -15,6 +15,8 @@ func TestDirection(t *testing.T) {
 		{"DirNone is 0", DirNone, 0},
 		{"DirUp is 1", DirUp, 1},
 		{"DirDown is 2", DirDown, 2},
+		{"DirLeft is 3", DirLeft, 3},
+		{"DirRight is 4", DirRight, 4},
 	}
 
 	for _, tt := range tests {
@@ -34,6 +36,8 @@ func TestTeam(t *testing.T) {
 	}{
 		{"TeamLeft is 0", TeamLeft, 0},
 		{"TeamRight is 1", TeamRight, 1},
+		{"TeamTop is 2", TeamTop, 2},
+		{"TeamBottom is 3", TeamBottom, 3},
 	}
 
 	for _, tt := range tests {
@@ -69,6 +73,13 @@ func TestGobRegistration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Resize",
+			message: Message{
+				Type:    MsgResize,
+				Payload: Resize{TerminalWidth: 120, TerminalHeight: 40},
+			},
+		},
 		{
 			name: "JoinResponse",
 			message: Message{
@@ -103,6 +114,7 @@ func TestGobRegistration(t *testing.T) {
 					Y:      10.0,
 					Height: 5,
 					Color:  1,
+					Name:   "Alice",
 				},
 			},
 		},
@@ -125,6 +137,41 @@ func TestGobRegistration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "GameStateWithPowerUps",
+			message: Message{
+				Type: MsgGameState,
+				Payload: GameState{
+					Tick:       200,
+					Ball:       BallState{X: 40.0, Y: 12.0, VX: 1.0, VY: 0.5},
+					ExtraBalls: []BallState{{X: 20.0, Y: 5.0, VX: -1.0, VY: 0.2}},
+					PowerUps: []PowerUpState{
+						{ID: 1, Kind: PowerUpShield, X: 38.5, Y: 9.0},
+					},
+					Effects: []EffectState{
+						{Kind: PowerUpReverse, Team: TeamRight, SecondsLeft: 4},
+					},
+					PowerUpsCollected: 3,
+					ShieldBlocks:      1,
+				},
+			},
+		},
+		{
+			name: "GameStateWithCourt",
+			message: Message{
+				Type: MsgGameState,
+				Payload: GameState{
+					CourtWidth:  80,
+					CourtHeight: 24,
+					Court: CourtLayout{
+						Blocks:     []BlockState{{X: 30, Y: 4, W: 2, H: 5}},
+						Bumpers:    []BumperState{{X: 40, Y: 12, R: 2}},
+						GoalTop:    6,
+						GoalBottom: 18,
+					},
+				},
+			},
+		},
 		{
 			name: "LobbyState",
 			message: Message{
@@ -141,6 +188,64 @@ func TestGobRegistration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TeamChoice",
+			message: Message{
+				Type:    MsgTeamChoice,
+				Payload: TeamChoice{Preference: PreferRight},
+			},
+		},
+		{
+			name: "LobbyStateWithTeams",
+			message: Message{
+				Type: MsgLobbyState,
+				Payload: LobbyState{
+					Players: []LobbyPlayer{
+						{ID: "p1", Name: "Alice", Color: 1, Preference: PreferLeft},
+						{ID: "p2", Name: "Bob", Color: 2, Preference: PreferRandom},
+					},
+					TeamsLocked: true,
+				},
+			},
+		},
+		{
+			name: "LobbyStateWithTournamentTeams",
+			message: Message{
+				Type: MsgLobbyState,
+				Payload: LobbyState{
+					Players: []LobbyPlayer{
+						{ID: "p1", Name: "Alice", Color: 1, Entrant: 2},
+						{ID: "p2", Name: "Bob", Color: 2},
+						{ID: "p3", Name: "Cy", Color: 3, Entrant: 1},
+					},
+					NotReady:   "3 players can't make teams of 2",
+					Tournament: "single",
+					TeamSize:   2,
+				},
+			},
+		},
+		{
+			name: "LobbyStateWithRules",
+			message: Message{
+				Type: MsgLobbyState,
+				Payload: LobbyState{
+					PointsToWin: 10,
+					Rules: RulesInfo{
+						Name:           "fast",
+						BallSpeed:      0.4,
+						MaxBounceAngle: 60,
+						SpeedIncrement: 1.08,
+						SpeedCap:       2.0,
+						PaddleHeight:   5,
+					},
+					Standings: []Standing{
+						{Name: "Alice", Wins: 12, Losses: 4, Streak: 3, BestStreak: 5},
+						{Name: "Bob", Wins: 4, Losses: 12, Streak: -2, BestStreak: 2},
+					},
+					Skipped: 1,
+				},
+			},
+		},
 		{
 			name: "GameOverState",
 			message: Message{
@@ -152,6 +257,54 @@ func TestGobRegistration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "GameOverStateWithStats",
+			message: Message{
+				Type: MsgGameOver,
+				Payload: GameOverState{
+					WinningTeam: TeamLeft,
+					LeftScore:   10,
+					RightScore:  7,
+					Stats: []PlayerStats{
+						{Name: "Alice", Team: TeamLeft, Hits: 24, Saves: 20, Goals: 8, Conceded: 3, TopSpeed: 54.2},
+						{Name: "Bob", Team: TeamRight, Hits: 19, Saves: 15, Goals: 6, OwnGoals: 1, Conceded: 9, TopSpeed: 48},
+					},
+					LongestRally: 14,
+					TopSpeed:     54.2,
+				},
+			},
+		},
+		{
+			name: "GameOverStateWithRatings",
+			message: Message{
+				Type: MsgGameOver,
+				Payload: GameOverState{
+					WinningTeam: TeamLeft,
+					LeftScore:   10,
+					RightScore:  7,
+					Ratings: []RatingChange{
+						{Name: "Alice", Before: 1500, After: 1516},
+						{Name: "Bob", Before: 1500, After: 1484},
+					},
+					SaveErrors: []string{"failed to write ratings: permission denied"},
+				},
+			},
+		},
+		{
+			name: "GameStateFourWay",
+			message: Message{
+				Type: MsgGameState,
+				Payload: GameState{
+					Paddles: []PaddleState{
+						{ID: "p3", Team: TeamTop, Column: 4, Y: 40.0, Height: 10, Color: 3, Horizontal: true},
+					},
+					Sides: []SideState{
+						{Team: TeamLeft, Score: 2},
+						{Team: TeamBottom, Conceded: 5, Out: true},
+					},
+				},
+			},
+		},
 		{
 			name: "RematchState",
 			message: Message{
@@ -166,6 +319,22 @@ func TestGobRegistration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "RematchStateWithBracket",
+			message: Message{
+				Type: MsgRematchState,
+				Payload: RematchState{
+					Players: []RematchPlayer{{ID: "p1", Name: "Alice", Color: 1}},
+					Bracket: &BracketState{
+						Format: "double",
+						Matches: []BracketMatch{
+							{Side: "winners", Round: 1, Names: [2]string{"Alice", "Bob"}, Winner: 0},
+							{Side: "final", Round: 1, Names: [2]string{"Alice", ""}, Winner: -1, Next: true},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Countdown",
 			message: Message{
@@ -185,6 +354,29 @@ func TestGobRegistration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "PauseStateWaitingForServe",
+			message: Message{
+				Type: MsgPauseState,
+				Payload: PauseState{
+					WaitingForServe:  true,
+					ServingTeam:      TeamLeft,
+					ServerName:       "Alice",
+					ServeSecondsLeft: 7,
+					PausedBy:         "Bob",
+					PausedTeam:       TeamRight,
+					PausesLeft:       1,
+					ResumeVotes:      1,
+					VotesNeeded:      2,
+					Game: &GameState{
+						Ball:        BallState{X: 40, Y: 12},
+						Paddles:     []PaddleState{{ID: "p1", Column: 2, Y: 15, Height: 5, Team: TeamLeft}},
+						CourtWidth:  80,
+						CourtHeight: 24,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -230,6 +422,11 @@ func TestMessageTypes(t *testing.T) {
 		MsgRematchState,
 		MsgCountdown,
 		MsgPauseState,
+		MsgServe,
+		MsgTeamChoice,
+		MsgReshuffle,
+		MsgPause,
+		MsgResize,
 	}
 
 	seen := make(map[MessageType]bool)
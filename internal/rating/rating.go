@@ -0,0 +1,130 @@
+package rating
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// Elo tuning
+const (
+	DefaultRating = 1500.0 // Rating of a player never seen before
+	KFactor       = 32.0   // Maximum rating change per match
+)
+
+// Change records how a player's rating moved after a match
+type Change struct {
+	Name   string
+	Before float64
+	After  float64
+}
+
+// Store keeps Elo ratings per player name, persisted as a JSON file
+type Store struct {
+	path    string
+	ratings map[string]float64
+}
+
+// Load reads the ratings file. A missing file gives an empty store.
+func Load(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		ratings: make(map[string]float64),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to read ratings: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.ratings); err != nil {
+		return s, fmt.Errorf("failed to parse ratings: %w", err)
+	}
+	return s, nil
+}
+
+// Save writes the ratings file, replacing the old one in one step
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s.ratings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode ratings: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create ratings directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write ratings: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write ratings: %w", err)
+	}
+	return nil
+}
+
+// Copy returns a store with the same ratings and file, to save while the
+// original keeps changing
+func (s *Store) Copy() *Store {
+	ratings := make(map[string]float64, len(s.ratings))
+	for name, r := range s.ratings {
+		ratings[name] = r
+	}
+	return &Store{path: s.path, ratings: ratings}
+}
+
+// Get returns a player's rating, or the default for new players
+func (s *Store) Get(name string) float64 {
+	if r, ok := s.ratings[name]; ok {
+		return r
+	}
+	return DefaultRating
+}
+
+// Update applies the result of a match between two teams. Each team plays
+// as its average rating, and every player on it moves by the same amount.
+func (s *Store) Update(winners, losers []string) []Change {
+	if len(winners) == 0 || len(losers) == 0 {
+		return nil
+	}
+
+	expected := Expected(s.average(winners), s.average(losers))
+	delta := KFactor * (1 - expected)
+
+	changes := make([]Change, 0, len(winners)+len(losers))
+	for _, name := range winners {
+		changes = append(changes, s.adjust(name, delta))
+	}
+	for _, name := range losers {
+		changes = append(changes, s.adjust(name, -delta))
+	}
+	return changes
+}
+
+// Expected returns the chance that a team rated a beats a team rated b
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// average returns the mean rating of a team
+func (s *Store) average(names []string) float64 {
+	total := 0.0
+	for _, name := range names {
+		total += s.Get(name)
+	}
+	return total / float64(len(names))
+}
+
+// adjust moves one player's rating and records the change
+func (s *Store) adjust(name string, delta float64) Change {
+	before := s.Get(name)
+	s.ratings[name] = before + delta
+	return Change{Name: name, Before: before, After: before + delta}
+}
@@ -0,0 +1,86 @@
+package rating
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpected(t *testing.T) {
+	if got := Expected(1500, 1500); math.Abs(got-0.5) > 0.0001 {
+		t.Errorf("expected 0.5 for equal ratings, got %f", got)
+	}
+	if got := Expected(1900, 1500); math.Abs(got-0.909) > 0.001 {
+		t.Errorf("expected about 0.909 for a 400 point gap, got %f", got)
+	}
+}
+
+func TestStore_Update(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "ratings.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	changes := s.Update([]string{"Alice", "Bob"}, []string{"Carol"})
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %d", len(changes))
+	}
+
+	// Equal teams: winners gain half the K factor, losers lose it
+	if got := s.Get("Alice"); math.Abs(got-(DefaultRating+KFactor/2)) > 0.0001 {
+		t.Errorf("expected Alice at %f, got %f", DefaultRating+KFactor/2, got)
+	}
+	if got := s.Get("Carol"); math.Abs(got-(DefaultRating-KFactor/2)) > 0.0001 {
+		t.Errorf("expected Carol at %f, got %f", DefaultRating-KFactor/2, got)
+	}
+
+	// Beating a weaker team is worth less
+	before := s.Get("Alice")
+	s.Update([]string{"Alice"}, []string{"Carol"})
+	if gain := s.Get("Alice") - before; gain >= KFactor/2 {
+		t.Errorf("expected a smaller gain against a weaker team, got %f", gain)
+	}
+
+	if changes := s.Update(nil, []string{"Carol"}); changes != nil {
+		t.Errorf("expected no changes without winners")
+	}
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "ratings.json")
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.Get("Alice"); got != DefaultRating {
+		t.Errorf("expected default rating for a new player, got %f", got)
+	}
+
+	s.Update([]string{"Alice"}, []string{"Bob"})
+	if err := s.Save(); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if loaded.Get("Alice") != s.Get("Alice") || loaded.Get("Bob") != s.Get("Bob") {
+		t.Errorf("expected ratings to survive a save and load")
+	}
+}
+
+func TestStore_Copy(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "ratings.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Update([]string{"Alice"}, []string{"Bob"})
+
+	c := s.Copy()
+	s.Update([]string{"Alice"}, []string{"Bob"})
+	if c.Get("Alice") == s.Get("Alice") || c.Get("Alice") <= DefaultRating {
+		t.Errorf("expected the copy to keep the ratings from when it was made, got %f and %f", c.Get("Alice"), s.Get("Alice"))
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/diegok/pixpong/internal/game"
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// BotIDBase is the first player ID used for bots, well above human client IDs
+const BotIDBase = 1000
+
+// newBots creates the bot players requested in the configuration
+func newBots(count int, difficultyName string) []*game.Bot {
+	difficulty, err := game.ParseDifficulty(difficultyName)
+	if err != nil {
+		difficulty = game.DifficultyNormal
+	}
+
+	bots := make([]*game.Bot, 0, count)
+	for i := 1; i <= count; i++ {
+		// Take colors from the end of the palette so bots stand out from the host
+		color := (8 - i%8) % 8
+		bots = append(bots, game.NewBot(BotIDBase+i, fmt.Sprintf("Bot %d", i), color, difficulty))
+	}
+	return bots
+}
+
+// playerCount returns the number of humans and bots taking part
+func (s *Server) playerCount() int {
+	return len(s.clients) + len(s.bots)
+}
+
+// driveBots feeds bot decisions into the game state (caller holds s.mu)
+func (s *Server) driveBots() {
+	for _, bot := range s.bots {
+		// Bots leave it to the humans to resume a paused match
+		if s.gameState.PausedBy != 0 {
+			continue
+		}
+		if bot.WantsServe(s.gameState) {
+			s.gameState.Serve(bot.ID)
+			continue
+		}
+		if dir := bot.Decide(s.gameState); dir != protocol.DirNone {
+			s.gameState.ProcessInput(bot.ID, dir)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"time"
+
+	"github.com/diegok/pixpong/internal/game"
+	"github.com/diegok/pixpong/internal/history"
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// teamKeys names the teams in the match history
+var teamKeys = map[protocol.Team]string{
+	protocol.TeamLeft:   "left",
+	protocol.TeamRight:  "right",
+	protocol.TeamTop:    "top",
+	protocol.TeamBottom: "bottom",
+}
+
+// decisionKeys names how a match was decided in the match history
+var decisionKeys = map[protocol.MatchDecision]string{
+	protocol.DecidedByPoints:       "points",
+	protocol.DecidedByTwoPointLead: "two_point_lead",
+	protocol.DecidedByTime:         "time",
+	protocol.DecidedByOvertime:     "overtime",
+	protocol.DecidedBySets:         "sets",
+	protocol.DecidedByLastStanding: "last_standing",
+}
+
+// recordMatch adds the finished match to the standings and returns it, to be
+// appended to the history file once s.mu is released (caller holds s.mu)
+func (s *Server) recordMatch(winner protocol.Team, decision protocol.MatchDecision) history.Match {
+	gs := s.gameState
+	m := history.Match{
+		Date:     time.Now(),
+		Winner:   teamKeys[winner],
+		Score:    make(map[string]int),
+		Seconds:  gs.Tick / TickRate,
+		Rules:    gs.Rules.Name,
+		FourWay:  gs.FourWay,
+		Decision: decisionKeys[decision],
+	}
+	if s.courtMap != nil {
+		m.Map = s.courtMap.Name
+	}
+
+	for _, player := range gs.Players {
+		paddle := gs.GetPaddle(player.ID)
+		if paddle == nil {
+			continue
+		}
+		m.Players = append(m.Players, history.Player{
+			Name: player.Name,
+			Team: teamKeys[paddle.Team],
+			Bot:  player.ID >= BotIDBase,
+		})
+	}
+
+	if gs.FourWay {
+		for _, team := range game.FourWayTeams {
+			m.Score[teamKeys[team]] = gs.SideScores[team]
+		}
+	} else {
+		m.Score[teamKeys[protocol.TeamLeft]] = gs.LeftScore
+		m.Score[teamKeys[protocol.TeamRight]] = gs.RightScore
+	}
+
+	s.matches = append(s.matches, m)
+	s.standings = history.Leaderboard(s.matches)
+
+	return m
+}
+
+// lobbyStandings returns the records of the people in the lobby, best
+// first (caller holds s.mu)
+func (s *Server) lobbyStandings() []protocol.Standing {
+	present := make(map[string]bool, len(s.clients))
+	for _, client := range s.clients {
+		present[client.Name] = true
+	}
+
+	var standings []protocol.Standing
+	for _, st := range s.standings {
+		if !present[st.Name] {
+			continue
+		}
+		standings = append(standings, protocol.Standing{
+			Name:       st.Name,
+			Wins:       st.Wins,
+			Losses:     st.Losses,
+			Streak:     st.Streak,
+			BestStreak: st.BestStreak,
+		})
+	}
+	return standings
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"math"
+
+	"github.com/diegok/pixpong/internal/game"
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/diegok/pixpong/internal/rating"
+)
+
+// botRatings gives bots a fixed rating per difficulty for team balancing
+var botRatings = map[game.Difficulty]float64{
+	game.DifficultyEasy:   rating.DefaultRating - 300,
+	game.DifficultyNormal: rating.DefaultRating,
+	game.DifficultyHard:   rating.DefaultRating + 300,
+}
+
+// updateRatings records the result of the finished match (caller holds s.mu).
+// Only matches between people count, so bots and solo games are ignored. It
+// returns a copy of the ratings to save once s.mu is released, nil when
+// nothing changed.
+func (s *Server) updateRatings(winner protocol.Team) ([]protocol.RatingChange, *rating.Store) {
+	var winners, losers []string
+	for _, player := range s.gameState.Players {
+		if player.ID >= BotIDBase {
+			continue
+		}
+		paddle := s.gameState.GetPaddle(player.ID)
+		if paddle == nil {
+			continue
+		}
+		if paddle.Team == winner {
+			winners = append(winners, player.Name)
+		} else {
+			losers = append(losers, player.Name)
+		}
+	}
+
+	changes := s.ratings.Update(winners, losers)
+	s.ratingDeltas = make(map[string]int, len(changes))
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	result := make([]protocol.RatingChange, 0, len(changes))
+	for _, change := range changes {
+		before, after := int(math.Round(change.Before)), int(math.Round(change.After))
+		result = append(result, protocol.RatingChange{
+			Name:   change.Name,
+			Before: before,
+			After:  after,
+		})
+		s.ratingDeltas[change.Name] = after - before
+	}
+	return result, s.ratings.Copy()
+}
+
+// BalanceByRating splits the lobby into teams of similar skill
+func (s *Server) BalanceByRating() {
+	s.mu.Lock()
+	if !s.inLobby || s.king != nil {
+		s.mu.Unlock()
+		return
+	}
+
+	ratings := make(map[int]float64, s.playerCount())
+	for id, client := range s.clients {
+		ratings[id] = s.ratings.Get(client.Name)
+	}
+	for _, bot := range s.bots {
+		ratings[bot.ID] = botRatings[bot.Difficulty]
+	}
+	if s.cfg.FourWay {
+		s.teamPicks = game.BalanceFourByRating(s.playerIDs(), ratings)
+	} else {
+		s.teamPicks = game.BalanceByRating(s.playerIDs(), ratings)
+	}
+	s.mu.Unlock()
+
+	s.BroadcastLobbyState()
+}
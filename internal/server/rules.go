@@ -0,0 +1,75 @@
+package server
+
+import (
+	"github.com/diegok/pixpong/internal/game"
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// ruleChoices returns the presets the host can cycle through in the lobby
+func ruleChoices() []game.Rules {
+	choices := make([]game.Rules, 0, len(game.RulePresets))
+	for _, name := range game.PresetNames() {
+		choices = append(choices, game.RulePresets[name])
+	}
+	return choices
+}
+
+// LoadRules sets the physics used by every match, from a preset name or a
+// rules file. An empty name keeps the classic rules.
+func (s *Server) LoadRules(nameOrPath string) error {
+	if nameOrPath == "" {
+		return nil
+	}
+
+	rules, err := game.LoadRules(nameOrPath)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Rules from a file join the presets the host can pick from, or take
+	// the place of the preset with the same name
+	for i, choice := range s.ruleChoices {
+		if choice.Name == rules.Name {
+			s.ruleChoices[i] = rules
+			s.rulesIndex = i
+			return nil
+		}
+	}
+	s.ruleChoices = append([]game.Rules{rules}, s.ruleChoices...)
+	s.rulesIndex = 0
+	return nil
+}
+
+// CycleRules switches to the next rules for the coming match
+func (s *Server) CycleRules() {
+	s.mu.Lock()
+	if !s.inLobby {
+		s.mu.Unlock()
+		return
+	}
+	s.rulesIndex = (s.rulesIndex + 1) % len(s.ruleChoices)
+	s.mu.Unlock()
+
+	s.BroadcastLobbyState()
+}
+
+// rules returns the rules for the next match (caller holds s.mu)
+func (s *Server) rules() game.Rules {
+	return s.ruleChoices[s.rulesIndex]
+}
+
+// rulesInfo summarizes the rules for the lobby (caller holds s.mu)
+func (s *Server) rulesInfo() protocol.RulesInfo {
+	rules := s.rules()
+	return protocol.RulesInfo{
+		Name:           rules.Name,
+		BallSpeed:      rules.BallSpeed,
+		MaxBounceAngle: int(rules.MaxBounceAngle + 0.5),
+		SpeedIncrement: rules.SpeedIncrement,
+		SpeedCap:       rules.BaseSpeedCap,
+		PaddleHeight:   rules.PaddleHeight,
+	}
+}
@@ -2,13 +2,17 @@ package server
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"sync"
 	"time"
 
 	"github.com/diegok/pixpong/internal/config"
 	"github.com/diegok/pixpong/internal/game"
+	"github.com/diegok/pixpong/internal/history"
 	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/diegok/pixpong/internal/rating"
+	"github.com/diegok/pixpong/internal/tournament"
 )
 
 // Server constants
@@ -26,26 +30,88 @@ type Server struct {
 	clients      map[int]*Client
 	nextID       int
 	gameState    *game.GameState
+	bots         []*game.Bot
+	ratings      *rating.Store
+	matches      []history.Match
+	standings    []history.Standing
+	skipped      int // Damaged history lines left out of the standings
+	courtMap     *game.Map
+	ruleChoices  []game.Rules
+	rulesIndex   int
+	ratingDeltas map[string]int // Rating changes from the last match, by name
+	teamPicks    map[int]protocol.TeamPreference
+	teamsLocked  bool
+	reshuffle    bool
 	inLobby      bool
 	inRematch    bool
 	rematchReady map[int]bool
+	bracket      *tournament.Bracket // Running tournament, nil outside one
+	entrants     []entrant
+	registered   map[int]int     // Tournament team number the host put each player in
+	noShows      map[int]bool    // Bracket matches given away because someone left
+	bracketMatch int             // Bracket match being played, -1 for none
+	king         *game.KingQueue // Winner-stays-on line, nil when off
 	minWidth     int
 	minHeight    int
 	done         chan struct{}
 }
 
-// NewServer creates a new server with the given configuration
-func NewServer(cfg *config.Config) *Server {
+// NewServer creates a new server with the given configuration. A ratings
+// file that can't be read stops it, so the next match doesn't overwrite it,
+// and so does a history file that can't be read at all.
+func NewServer(cfg *config.Config) (*Server, error) {
+	ratings, err := rating.Load(cfg.RatingsFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w (fix or remove %s)", err, cfg.RatingsFile)
+	}
+	matches, skipped, err := history.Load(cfg.HistoryFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w (fix or remove %s)", err, cfg.HistoryFile)
+	}
+
+	var king *game.KingQueue
+	if cfg.King > 0 {
+		king = game.NewKingQueue(cfg.King)
+	}
+
 	return &Server{
 		cfg:          cfg,
 		clients:      make(map[int]*Client),
 		nextID:       1,
 		inLobby:      true,
 		rematchReady: make(map[int]bool),
+		teamPicks:    make(map[int]protocol.TeamPreference),
+		registered:   make(map[int]int),
+		bots:         newBots(cfg.Bots, cfg.Difficulty),
+		ratings:      ratings,
+		matches:      matches,
+		standings:    history.Leaderboard(matches),
+		skipped:      skipped,
+		ruleChoices:  ruleChoices(),
+		bracketMatch: -1,
+		king:         king,
 		minWidth:     MinTermWidth,
 		minHeight:    MinTermHeight,
 		done:         make(chan struct{}),
+	}, nil
+}
+
+// LoadMap loads the court layout used by every match. An empty path keeps
+// the plain court.
+func (s *Server) LoadMap(path string) error {
+	if path == "" {
+		return nil
 	}
+
+	m, err := game.LoadMap(path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.courtMap = m
+	s.mu.Unlock()
+	return nil
 }
 
 // Start begins listening for connections
@@ -62,6 +128,14 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// ConnectLocal returns an in-memory connection to the server, without going
+// through the network. Used by solo mode, where the server is never started.
+func (s *Server) ConnectLocal() net.Conn {
+	serverConn, clientConn := net.Pipe()
+	go s.handleConnection(serverConn)
+	return clientConn
+}
+
 // Stop gracefully shuts down the server
 func (s *Server) Stop() {
 	s.mu.Lock()
@@ -227,14 +301,8 @@ func (s *Server) handleConnection(conn net.Conn) {
 	// Add to clients map
 	s.mu.Lock()
 	s.clients[clientID] = client
-
-	// Update min terminal size
-	if client.Width < s.minWidth {
-		s.minWidth = client.Width
-	}
-	if client.Height < s.minHeight {
-		s.minHeight = client.Height
-	}
+	s.syncKing()
+	s.updateMinSize()
 	s.mu.Unlock()
 
 	// Start client writer
@@ -273,28 +341,57 @@ func (s *Server) handleDisconnect(clientID int) {
 		return
 	}
 
+	// Only a player in the match being played ends it. Tournament
+	// spectators, knocked out players and the winner-stays-on line just
+	// leave. The line can take in newcomers mid-match, so go by who is
+	// actually playing.
+	playing := s.gameState != nil && !s.inLobby && !s.inRematch
+	wasInGame := playing && s.onCourt(clientID)
+
 	client.Close()
 	delete(s.clients, clientID)
 	delete(s.rematchReady, clientID)
-
-	// If game is in progress, end it
-	wasInGame := s.gameState != nil && !s.inLobby && !s.inRematch
+	delete(s.teamPicks, clientID)
+	delete(s.registered, clientID)
+	s.syncKing()
 	s.mu.Unlock()
 
-	if wasInGame {
-		// End the game due to disconnect
+	switch {
+	case wasInGame:
+		// End the game due to disconnect. A tournament goes back to the
+		// bracket, where the match is played again or given away.
 		s.mu.Lock()
-		s.inLobby = true
+		inTournament := s.bracket != nil
+		s.inLobby = !inTournament
 		s.gameState = nil
+		s.syncKing() // The next in line take the empty places
 		s.mu.Unlock()
-		s.BroadcastLobbyState()
-	} else if s.inRematch {
+		if inTournament {
+			s.ResetForRematch()
+			s.BroadcastRematchState()
+		} else {
+			s.BroadcastLobbyState()
+		}
+	case playing:
+		// The match goes on without them
+	case s.inRematch:
 		s.BroadcastRematchState()
-	} else {
+	default:
 		s.BroadcastLobbyState()
 	}
 }
 
+// onCourt returns true if the player takes part in the match being played
+// (caller holds s.mu)
+func (s *Server) onCourt(id int) bool {
+	for _, player := range s.gameState.Players {
+		if player.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 // handleMessage processes incoming messages from clients
 func (s *Server) handleMessage(client *Client, msg *protocol.Message) {
 	switch msg.Type {
@@ -317,8 +414,78 @@ func (s *Server) handleMessage(client *Client, msg *protocol.Message) {
 		}
 		s.mu.Unlock()
 
+	case protocol.MsgPause:
+		s.mu.Lock()
+		if s.gameState != nil && !s.gameState.RequestPause(client.PlayerID) {
+			s.gameState.VoteResume(client.PlayerID)
+		}
+		s.mu.Unlock()
+
+	case protocol.MsgResize:
+		size, ok := msg.Payload.(protocol.Resize)
+		if !ok {
+			return
+		}
+
+		// Takes effect from the next match, the current court stays put
+		s.mu.Lock()
+		client.Width = size.TerminalWidth
+		client.Height = size.TerminalHeight
+		s.updateMinSize()
+		s.mu.Unlock()
+
 	case protocol.MsgRematchReady:
 		s.SetClientRematchReady(client.ID)
+
+	case protocol.MsgTeamChoice:
+		choice, ok := msg.Payload.(protocol.TeamChoice)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		changed := s.inLobby && !s.teamsLocked && s.validPick(choice.Preference)
+		if changed {
+			s.teamPicks[client.ID] = choice.Preference
+		}
+		s.mu.Unlock()
+
+		if changed {
+			s.BroadcastLobbyState()
+		}
+
+	case protocol.MsgReshuffle:
+		s.mu.Lock()
+		inRematch := s.inRematch
+		if inRematch {
+			s.reshuffle = true
+		}
+		s.mu.Unlock()
+
+		if inRematch {
+			s.BroadcastRematchState()
+		}
+	}
+}
+
+// updateMinSize finds the smallest terminal to size the court by. Terminals
+// below the minimum show a warning instead of the court, so they are left
+// out until they grow back. (caller holds s.mu)
+func (s *Server) updateMinSize() {
+	s.minWidth, s.minHeight = 0, 0
+	for _, client := range s.clients {
+		if client.Width < MinTermWidth || client.Height < MinTermHeight {
+			continue
+		}
+		if s.minWidth == 0 || client.Width < s.minWidth {
+			s.minWidth = client.Width
+		}
+		if s.minHeight == 0 || client.Height < s.minHeight {
+			s.minHeight = client.Height
+		}
+	}
+	if s.minWidth == 0 {
+		s.minWidth, s.minHeight = MinTermWidth, MinTermHeight
 	}
 }
 
@@ -327,32 +494,69 @@ func (s *Server) StartGame() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if len(s.clients) < 2 {
+	if s.playerCount() < 2 {
 		return
 	}
+	s.syncKing()
 
-	// Recalculate min terminal size from all clients
-	s.minWidth = MinTermWidth
-	s.minHeight = MinTermHeight
-	for _, client := range s.clients {
-		if client.Width < s.minWidth {
-			s.minWidth = client.Width
+	// Recalculate min terminal size from all clients, as they are now
+	s.updateMinSize()
+
+	// Create game state on the host's fixed court, or the smallest terminal
+	// less its status bars, never below the minimum court. Every client
+	// scales the court to fit, so the shape stays the same.
+	width, height := s.minWidth, max(s.minHeight-2, config.MinCourtHeight)
+	if s.cfg.CourtWidth > 0 {
+		width, height = s.cfg.CourtWidth, s.cfg.CourtHeight
+	}
+	s.gameState = game.NewGameState(width, height, s.cfg.PointsToWin)
+	s.gameState.Rules = s.rules()
+
+	// Add all players to the game, or just the entrants of a tournament match
+	for _, id := range s.clientIDs() {
+		if s.inMatch(id) {
+			s.gameState.AddPlayer(id, s.clients[id].Name)
 		}
-		if client.Height < s.minHeight {
-			s.minHeight = client.Height
+	}
+	for _, bot := range s.bots {
+		if !s.inMatch(bot.ID) {
+			continue
 		}
+		paddle := s.gameState.AddBot(bot.ID, bot.Name)
+		paddle.Color = bot.Color
 	}
 
-	// Create game state with minimum terminal size
-	s.gameState = game.NewGameState(s.minWidth, s.minHeight, s.cfg.PointsToWin)
+	s.gameState.SetMap(s.courtMap)
+	s.gameState.FourWay = s.cfg.FourWay
+	s.gameState.Lateral, _ = game.ParseLateralMode(s.cfg.Lateral)
+	s.gameState.ServeRule, _ = game.ParseServeRule(s.cfg.ServeRule)
+	s.gameState.ServeTimeout = int(s.cfg.ServeTime.Seconds() * TickRate)
+	s.gameState.PauseLimit = s.cfg.Pauses
+	s.gameState.PowerUpsEnabled = s.cfg.PowerUps
+	s.gameState.BallCount = s.cfg.Balls
+	s.gameState.BallEvery = s.cfg.BallEvery
+	s.gameState.Format = game.MatchFormat{
+		WinByTwo:  s.cfg.WinByTwo,
+		TimeLimit: int(s.cfg.TimeLimit.Seconds() * TickRate),
+		Sets:      s.cfg.Sets,
+	}
 
-	// Add all players to the game
-	for _, client := range s.clients {
-		s.gameState.AddPlayer(client.ID, client.Name)
+	// Assign teams from the lobby picks, or randomly if a reshuffle was asked
+	if s.reshuffle {
+		s.teamPicks = make(map[int]protocol.TeamPreference)
+		s.reshuffle = false
+	}
+	if s.bracket != nil {
+		s.teamPicks = s.bracketPicks()
+	} else if s.king != nil {
+		s.teamPicks = s.king.Picks()
 	}
+	s.gameState.AssignTeamsByPreference(s.teamPicks)
 
-	// Assign teams randomly
-	s.gameState.AssignTeams()
+	// Keep the same teams for the rematch
+	for _, p := range s.gameState.Paddles {
+		s.teamPicks[p.ID] = game.PreferenceFor(p.Team)
+	}
 
 	s.inLobby = false
 	s.inRematch = false
@@ -360,6 +564,12 @@ func (s *Server) StartGame() {
 
 // StartGameWithCountdown starts the game with a 3,2,1 countdown
 func (s *Server) StartGameWithCountdown() {
+	// A tournament can run out of matches when players leave
+	if s.cfg.Tournament != "" && !s.PrepareTournamentMatch() {
+		s.BroadcastRematchState()
+		return
+	}
+
 	// Send countdown 3, 2, 1
 	for i := 3; i > 0; i-- {
 		s.broadcast(&protocol.Message{
@@ -393,7 +603,8 @@ func (s *Server) gameLoop() {
 				return
 			}
 
-			// Update game state
+			// Let bots move and serve, then update game state
+			s.driveBots()
 			s.gameState.Update()
 
 			// Check if game is over
@@ -401,17 +612,37 @@ func (s *Server) gameLoop() {
 
 			// Prepare state to broadcast
 			var msg *protocol.Message
-			if s.gameState.Paused || s.gameState.WaitingForServe {
+			if s.gameState.Paused || s.gameState.WaitingForServe || s.gameState.PausedBy != 0 {
+				pause := protocol.PauseState{
+					SecondsLeft:      s.gameState.PauseTicksLeft / TickRate,
+					LeftScore:        s.gameState.LeftScore,
+					RightScore:       s.gameState.RightScore,
+					LastScorer:       s.gameState.LastScorer,
+					WaitingForServe:  s.gameState.WaitingForServe,
+					ServingTeam:      s.gameState.ServingTeam,
+					ServerName:       s.gameState.PlayerName(s.gameState.ServingPlayer),
+					ServeSecondsLeft: (s.gameState.ServeTicksLeft + TickRate - 1) / TickRate,
+					SetEnded:         s.gameState.SetJustEnded,
+					Match:            s.gameState.MatchInfo(),
+					Sides:            s.gameState.SideStates(),
+				}
+				// Show the court while waiting for serve, so the server can aim,
+				// and while a player has the match paused
+				if s.gameState.WaitingForServe || s.gameState.PausedBy != 0 {
+					court := s.gameState.ToProtocolState()
+					pause.Game = &court
+				}
+				if paddle := s.gameState.GetPaddle(s.gameState.PausedBy); paddle != nil {
+					pause.PausedBy = s.gameState.PlayerName(paddle.ID)
+					pause.PausedTeam = paddle.Team
+					pause.PausesLeft = s.gameState.PausesLeft(paddle.Team)
+					pause.ResumeVotes = s.gameState.ResumeVotes()
+					pause.VotesNeeded = s.gameState.VotesNeeded()
+					pause.ResumeSecondsLeft = (s.gameState.ResumeTicks + TickRate - 1) / TickRate
+				}
 				msg = &protocol.Message{
-					Type: protocol.MsgPauseState,
-					Payload: protocol.PauseState{
-						SecondsLeft:     s.gameState.PauseTicksLeft / TickRate,
-						LeftScore:       s.gameState.LeftScore,
-						RightScore:      s.gameState.RightScore,
-						LastScorer:      s.gameState.LastScorer,
-						WaitingForServe: s.gameState.WaitingForServe,
-						ServingTeam:     s.gameState.ServingTeam,
-					},
+					Type:    protocol.MsgPauseState,
+					Payload: pause,
 				}
 			} else {
 				msg = &protocol.Message{
@@ -447,20 +678,58 @@ func (s *Server) BroadcastLobbyState() {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// Build player list
+	// Build player list, in join order so it doesn't jump around
 	players := make([]protocol.LobbyPlayer, 0, len(s.clients))
-	for _, client := range s.clients {
+	for _, id := range s.clientIDs() {
+		client := s.clients[id]
+		players = append(players, protocol.LobbyPlayer{
+			ID:         fmt.Sprintf("%d", client.ID),
+			Name:       client.Name,
+			Color:      (client.ID - 1) % 8,
+			Preference: s.teamPicks[client.ID],
+			Rating:     int(math.Round(s.ratings.Get(client.Name))),
+			QueuePos:   s.queuePos(client.ID),
+			Entrant:    s.registered[client.ID],
+		})
+	}
+	for _, bot := range s.bots {
 		players = append(players, protocol.LobbyPlayer{
-			ID:    fmt.Sprintf("%d", client.ID),
-			Name:  client.Name,
-			Color: (client.ID - 1) % 8,
+			ID:         fmt.Sprintf("%d", bot.ID),
+			Name:       bot.Name,
+			Color:      bot.Color,
+			IsBot:      true,
+			Preference: s.teamPicks[bot.ID],
+			QueuePos:   s.queuePos(bot.ID),
+			Entrant:    s.registered[bot.ID],
 		})
 	}
 
-	// Get server addresses
-	addresses := s.GetServerAddresses()
+	// Get server addresses (none when only playing locally)
+	var addresses []string
+	if s.listener != nil {
+		addresses = s.GetServerAddresses()
+	}
+
+	canStart := s.playerCount() >= 2 && s.teamsReady()
+	notReady := ""
+	if s.cfg.Tournament != "" {
+		// Sides come from the bracket, it only needs two full entrants
+		teams, err := tournament.Teams(s.playerIDs(), s.registered, s.cfg.TeamSize)
+		canStart = err == nil && len(teams) >= 2
+		if err != nil && s.playerCount() > s.cfg.TeamSize {
+			notReady = err.Error()
+		}
+	}
+
+	botDifficulty := ""
+	if len(s.bots) > 0 {
+		botDifficulty = s.bots[0].Difficulty.String()
+	}
 
-	canStart := len(s.clients) >= 2
+	mapName := ""
+	if s.courtMap != nil {
+		mapName = s.courtMap.Name
+	}
 
 	// Send to each client
 	for _, client := range s.clients {
@@ -469,11 +738,34 @@ func (s *Server) BroadcastLobbyState() {
 		msg := &protocol.Message{
 			Type: protocol.MsgLobbyState,
 			Payload: protocol.LobbyState{
-				Players:     players,
-				IsHost:      isHost,
-				CanStart:    canStart,
-				ServerAddrs: nil, // Only host sees addresses
-				PointsToWin: s.cfg.PointsToWin,
+				Players:       players,
+				IsHost:        isHost,
+				CanStart:      canStart,
+				NotReady:      notReady,
+				TeamsLocked:   s.teamsLocked,
+				ServerAddrs:   nil, // Only host sees addresses
+				PointsToWin:   s.cfg.PointsToWin,
+				BotDifficulty: botDifficulty,
+				PowerUps:      s.cfg.PowerUps,
+				Balls:         s.cfg.Balls,
+				BallEvery:     s.cfg.BallEvery,
+				WinByTwo:      s.cfg.WinByTwo,
+				TimeLimit:     int(s.cfg.TimeLimit.Seconds()),
+				Sets:          s.cfg.Sets,
+				MapName:       mapName,
+				FourWay:       s.cfg.FourWay,
+				Lateral:       s.cfg.Lateral,
+				Rules:         s.rulesInfo(),
+				ServeRule:     s.cfg.ServeRule,
+				ServeTime:     int(s.cfg.ServeTime.Seconds()),
+				Pauses:        s.cfg.Pauses,
+				Standings:     s.lobbyStandings(),
+				Skipped:       s.skipped,
+				Tournament:    s.cfg.Tournament,
+				TeamSize:      s.cfg.TeamSize,
+				King:          s.cfg.King,
+				CourtWidth:    s.cfg.CourtWidth,
+				CourtHeight:   s.cfg.CourtHeight,
 			},
 		}
 
@@ -496,21 +788,38 @@ func (s *Server) BroadcastRematchState() {
 	// Build player list with ready status
 	players := make([]protocol.RematchPlayer, 0, len(s.clients))
 	allReady := true
-	for _, client := range s.clients {
+	for _, id := range s.clientIDs() {
+		client := s.clients[id]
 		ready := s.rematchReady[client.ID]
 		if !ready {
 			allReady = false
 		}
 		players = append(players, protocol.RematchPlayer{
-			ID:    fmt.Sprintf("%d", client.ID),
-			Name:  client.Name,
-			Color: (client.ID - 1) % 8,
-			Ready: ready,
+			ID:          fmt.Sprintf("%d", client.ID),
+			Name:        client.Name,
+			Color:       (client.ID - 1) % 8,
+			Ready:       ready,
+			Team:        s.lastTeam(client.ID),
+			Rating:      int(math.Round(s.ratings.Get(client.Name))),
+			RatingDelta: s.ratingDeltas[client.Name],
+			QueuePos:    s.queuePos(client.ID),
+		})
+	}
+	for _, bot := range s.bots {
+		// Bots are always ready for another round
+		players = append(players, protocol.RematchPlayer{
+			ID:       fmt.Sprintf("%d", bot.ID),
+			Name:     bot.Name,
+			Color:    bot.Color,
+			Ready:    true,
+			IsBot:    true,
+			Team:     s.lastTeam(bot.ID),
+			QueuePos: s.queuePos(bot.ID),
 		})
 	}
 
 	// Need at least 2 players and all must be ready
-	if len(s.clients) < 2 {
+	if s.playerCount() < 2 {
 		allReady = false
 	}
 
@@ -521,9 +830,12 @@ func (s *Server) BroadcastRematchState() {
 		msg := &protocol.Message{
 			Type: protocol.MsgRematchState,
 			Payload: protocol.RematchState{
-				Players:  players,
-				IsHost:   isHost,
-				AllReady: allReady,
+				Players:   players,
+				IsHost:    isHost,
+				AllReady:  allReady,
+				Reshuffle: s.reshuffle,
+				Bracket:   s.bracketState(),
+				King:      s.cfg.King,
 			},
 		}
 
@@ -553,23 +865,43 @@ func (s *Server) SetClientRematchReady(clientID int) {
 
 // broadcastGameOver sends the game over state to all clients
 func (s *Server) broadcastGameOver() {
-	s.mu.RLock()
+	s.mu.Lock()
 	if s.gameState == nil {
-		s.mu.RUnlock()
+		s.mu.Unlock()
 		return
 	}
 
-	msg := &protocol.Message{
-		Type: protocol.MsgGameOver,
-		Payload: protocol.GameOverState{
-			WinningTeam: s.gameState.GetWinner(),
-			LeftScore:   s.gameState.LeftScore,
-			RightScore:  s.gameState.RightScore,
-		},
+	winner, decision, _ := s.gameState.MatchResult()
+	ratings, ratingsToSave := s.updateRatings(winner)
+	match := s.recordMatch(winner, decision)
+	state := protocol.GameOverState{
+		WinningTeam: winner,
+		LeftScore:   s.gameState.LeftScore,
+		RightScore:  s.gameState.RightScore,
+		Decision:    decision,
+		Match:       s.gameState.MatchInfo(),
+		Ratings:     ratings,
+		Sides:       s.gameState.SideStates(),
+
+		Stats:        s.gameState.PlayerStatsList(),
+		LongestRally: s.gameState.Stats.LongestRally,
+		TopSpeed:     s.gameState.Stats.TopSpeed * TickRate,
+	}
+	s.reportBracket(winner)
+	s.rotateKing(winner)
+	s.mu.Unlock()
+
+	// Write the files without holding up the clients
+	if ratingsToSave != nil {
+		if err := ratingsToSave.Save(); err != nil {
+			state.SaveErrors = append(state.SaveErrors, err.Error())
+		}
+	}
+	if err := history.Append(s.cfg.HistoryFile, match); err != nil {
+		state.SaveErrors = append(state.SaveErrors, err.Error())
 	}
-	s.mu.RUnlock()
 
-	s.broadcast(msg)
+	s.broadcast(&protocol.Message{Type: protocol.MsgGameOver, Payload: state})
 
 	// Enter rematch mode
 	s.ResetForRematch()
@@ -589,4 +921,5 @@ func (s *Server) removeClient(clientID int) {
 	client.Close()
 	delete(s.clients, clientID)
 	delete(s.rematchReady, clientID)
+	delete(s.teamPicks, clientID)
 }
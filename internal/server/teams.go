@@ -0,0 +1,143 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/diegok/pixpong/internal/game"
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// clientIDs returns the connected client IDs in join order (caller holds s.mu)
+func (s *Server) clientIDs() []int {
+	ids := make([]int, 0, len(s.clients))
+	for id := range s.clients {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// playerIDs returns every human and bot player ID (caller holds s.mu)
+func (s *Server) playerIDs() []int {
+	ids := s.clientIDs()
+	for _, bot := range s.bots {
+		ids = append(ids, bot.ID)
+	}
+	return ids
+}
+
+// lastTeam returns the side a player was on in the last match (caller holds s.mu)
+func (s *Server) lastTeam(playerID int) protocol.Team {
+	switch s.teamPicks[playerID] {
+	case protocol.PreferRight:
+		return protocol.TeamRight
+	case protocol.PreferTop:
+		return protocol.TeamTop
+	case protocol.PreferBottom:
+		return protocol.TeamBottom
+	}
+	return protocol.TeamLeft
+}
+
+// validPick returns true if the team can be picked in this game mode
+func (s *Server) validPick(pref protocol.TeamPreference) bool {
+	if s.king != nil {
+		return false // The line decides who plays where
+	}
+	if pref == protocol.PreferTop || pref == protocol.PreferBottom {
+		return s.cfg.FourWay
+	}
+	return true
+}
+
+// teamsReady returns true if the lobby picks leave at least two teams with
+// players on them (caller holds s.mu)
+func (s *Server) teamsReady() bool {
+	if s.cfg.FourWay {
+		teams := 0
+		for _, members := range game.ResolveFourTeams(s.playerIDs(), s.teamPicks) {
+			if len(members) > 0 {
+				teams++
+			}
+		}
+		return teams >= 2
+	}
+
+	left, right := game.ResolveTeams(s.playerIDs(), s.teamPicks)
+	return len(left) > 0 && len(right) > 0
+}
+
+// MovePlayer puts a player on a team, or back to random. Used by the host,
+// so it works even when team selection is locked.
+func (s *Server) MovePlayer(playerID int, pref protocol.TeamPreference) {
+	s.mu.Lock()
+	if !s.inLobby || !s.validPick(pref) {
+		s.mu.Unlock()
+		return
+	}
+	s.teamPicks[playerID] = pref
+	s.mu.Unlock()
+
+	s.BroadcastLobbyState()
+}
+
+// ToggleTeamsLocked stops or allows players picking their own team
+func (s *Server) ToggleTeamsLocked() {
+	s.mu.Lock()
+	s.teamsLocked = !s.teamsLocked
+	s.mu.Unlock()
+
+	s.BroadcastLobbyState()
+}
+
+// AutoBalance evens out team sizes, keeping players' picks where possible.
+// Four-way teams are dealt out again from scratch.
+func (s *Server) AutoBalance() {
+	s.mu.Lock()
+	if !s.inLobby || s.king != nil {
+		s.mu.Unlock()
+		return
+	}
+	if s.cfg.FourWay {
+		s.teamPicks = make(map[int]protocol.TeamPreference)
+	} else {
+		s.teamPicks = game.BalanceTeams(s.playerIDs(), s.teamPicks)
+	}
+	s.mu.Unlock()
+
+	s.BroadcastLobbyState()
+}
+
+// syncKing updates the winner-stays-on line with who is here and takes the
+// sides from it (caller holds s.mu)
+func (s *Server) syncKing() {
+	if s.king == nil {
+		return
+	}
+	// Nobody steps onto the court of a match being played
+	if s.gameState != nil && !s.inLobby && !s.inRematch {
+		s.king.Queue(s.playerIDs())
+		return
+	}
+	s.king.Sync(s.playerIDs())
+	s.teamPicks = s.king.Picks()
+}
+
+// rotateKing sends the losers of the last match to the back of the line
+// (caller holds s.mu)
+func (s *Server) rotateKing(winner protocol.Team) {
+	if s.king == nil {
+		return
+	}
+	s.king.Rotate(s.playerIDs(), winner)
+	s.teamPicks = s.king.Picks()
+}
+
+// queuePos returns a player's place in the winner-stays-on line, 0 when on
+// court or when it is off (caller holds s.mu)
+func (s *Server) queuePos(id int) int {
+	if s.king == nil {
+		return 0
+	}
+	return s.king.Position(id)
+}
@@ -0,0 +1,249 @@
+package server
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/diegok/pixpong/internal/tournament"
+)
+
+// entrant is one side of a tournament, a player or a team of them
+type entrant struct {
+	name   string
+	ids    []int
+	rating float64 // Average of the players, for seeding
+}
+
+// playerName returns the name of a human or bot player (caller holds s.mu)
+func (s *Server) playerName(id int) string {
+	if client, ok := s.clients[id]; ok {
+		return client.Name
+	}
+	for _, bot := range s.bots {
+		if bot.ID == id {
+			return bot.Name
+		}
+	}
+	return ""
+}
+
+// playerRating returns a player's rating, bots rated by difficulty (caller
+// holds s.mu)
+func (s *Server) playerRating(id int) float64 {
+	for _, bot := range s.bots {
+		if bot.ID == id {
+			return botRatings[bot.Difficulty]
+		}
+	}
+	return s.ratings.Get(s.playerName(id))
+}
+
+// startTournament enters everyone in the lobby, in the teams the host
+// registered, and draws the bracket seeded by rating (caller holds s.mu)
+func (s *Server) startTournament() bool {
+	format, err := tournament.ParseFormat(s.cfg.Tournament)
+	if err != nil {
+		return false
+	}
+	teams, err := tournament.Teams(s.playerIDs(), s.registered, s.cfg.TeamSize)
+	if err != nil {
+		return false
+	}
+
+	var entrants []entrant
+	for _, team := range teams {
+		names := make([]string, len(team))
+		total := 0.0
+		for j, id := range team {
+			names[j] = s.playerName(id)
+			total += s.playerRating(id)
+		}
+		entrants = append(entrants, entrant{
+			name:   strings.Join(names, " & "),
+			ids:    team,
+			rating: total / float64(len(team)),
+		})
+	}
+
+	// Best rated entrants are seeded first, so they meet late
+	sort.SliceStable(entrants, func(i, j int) bool {
+		return entrants[i].rating > entrants[j].rating
+	})
+
+	names := make([]string, len(entrants))
+	for i, e := range entrants {
+		names[i] = e.name
+	}
+	bracket, err := tournament.New(format, names)
+	if err != nil {
+		return false
+	}
+
+	s.bracket = bracket
+	s.entrants = entrants
+	s.noShows = make(map[int]bool)
+	return true
+}
+
+// RegisterPlayer puts a player in a numbered tournament team, or with 0
+// leaves them to fill any open place. Used by the host in the lobby.
+func (s *Server) RegisterPlayer(playerID, team int) {
+	s.mu.Lock()
+	size := s.cfg.TeamSize
+	if !s.inLobby || s.cfg.Tournament == "" || size < 2 || team < 0 || team > (s.playerCount()+size-1)/size {
+		s.mu.Unlock()
+		return
+	}
+	if team == 0 {
+		delete(s.registered, playerID)
+	} else {
+		s.registered[playerID] = team
+	}
+	s.mu.Unlock()
+
+	s.BroadcastLobbyState()
+}
+
+// present returns true if anyone of the entrant is still here (caller holds s.mu)
+func (s *Server) present(e int) bool {
+	for _, id := range s.entrants[e].ids {
+		if _, ok := s.clients[id]; ok || id >= BotIDBase {
+			return true
+		}
+	}
+	return false
+}
+
+// PrepareTournamentMatch starts the tournament if needed and finds the next
+// match, giving walkovers against anyone who left. Returns false if there
+// is no match to play.
+func (s *Server) PrepareTournamentMatch() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.bracket == nil && !s.startTournament() {
+		return false
+	}
+
+	for {
+		next := s.bracket.Next()
+		if next < 0 {
+			s.bracketMatch = -1
+			return false
+		}
+
+		m := s.bracket.Matches[next]
+		first, second := s.present(m.Entrants[0]), s.present(m.Entrants[1])
+		if first && second {
+			s.bracketMatch = next
+			return true
+		}
+		slot := 0
+		if !first {
+			slot = 1
+		}
+		s.bracket.Report(next, slot)
+		s.noShows[next] = true
+	}
+}
+
+// inMatch returns true if the player takes part in the next match. Outside
+// a tournament or winner-stays-on everybody plays. (caller holds s.mu)
+func (s *Server) inMatch(id int) bool {
+	if s.king != nil {
+		_, onCourt := s.king.Court[id]
+		return onCourt
+	}
+	if s.bracket == nil || s.bracketMatch < 0 {
+		return true
+	}
+	for _, e := range s.bracket.Matches[s.bracketMatch].Entrants {
+		for _, member := range s.entrants[e].ids {
+			if member == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bracketPicks puts the first entrant of the match on the left and the
+// second on the right (caller holds s.mu)
+func (s *Server) bracketPicks() map[int]protocol.TeamPreference {
+	picks := make(map[int]protocol.TeamPreference)
+	m := s.bracket.Matches[s.bracketMatch]
+	for _, id := range s.entrants[m.Entrants[0]].ids {
+		picks[id] = protocol.PreferLeft
+	}
+	for _, id := range s.entrants[m.Entrants[1]].ids {
+		picks[id] = protocol.PreferRight
+	}
+	return picks
+}
+
+// reportBracket records the winner of the match just played (caller holds s.mu)
+func (s *Server) reportBracket(winner protocol.Team) {
+	if s.bracket == nil || s.bracketMatch < 0 {
+		return
+	}
+	slot := 0
+	if winner == protocol.TeamRight {
+		slot = 1
+	}
+	s.bracket.Report(s.bracketMatch, slot)
+	s.bracketMatch = -1
+}
+
+// bracketState describes the tournament for clients, nil outside one
+// (caller holds s.mu)
+func (s *Server) bracketState() *protocol.BracketState {
+	if s.bracket == nil {
+		return nil
+	}
+
+	state := &protocol.BracketState{Format: s.bracket.Format.String()}
+	next := s.bracket.Next()
+	for i, m := range s.bracket.Matches {
+		bm := protocol.BracketMatch{
+			Side:     m.Side.String(),
+			Round:    m.Round,
+			Winner:   -1,
+			Walkover: m.Walkover || s.noShows[i],
+			Next:     i == next,
+		}
+		for slot, e := range m.Entrants {
+			if e >= 0 {
+				bm.Names[slot] = s.entrants[e].name
+			}
+		}
+		if m.Winner >= 0 {
+			bm.Winner = 0
+			if m.Winner == m.Entrants[1] {
+				bm.Winner = 1
+			}
+		}
+		state.Matches = append(state.Matches, bm)
+	}
+	if champion := s.bracket.Champion(); champion >= 0 {
+		state.Champion = s.entrants[champion].name
+	}
+	return state
+}
+
+// EndTournament clears a finished tournament and goes back to the lobby
+func (s *Server) EndTournament() {
+	s.mu.Lock()
+	if s.bracket == nil || !s.bracket.Done() {
+		s.mu.Unlock()
+		return
+	}
+	s.bracket = nil
+	s.entrants = nil
+	s.noShows = nil
+	s.inLobby = true
+	s.inRematch = false
+	s.mu.Unlock()
+
+	s.BroadcastLobbyState()
+}
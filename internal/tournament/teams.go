@@ -0,0 +1,55 @@
+package tournament
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Teams groups players into entrants of size players each. Players the host
+// registered under the same team number play together, teams in number
+// order, and everyone else fills the open places in the order given.
+func Teams(ids []int, registered map[int]int, size int) ([][]int, error) {
+	if size < 1 {
+		size = 1
+	}
+	if len(ids)%size != 0 {
+		return nil, fmt.Errorf("%d players can't make teams of %d", len(ids), size)
+	}
+
+	byNumber := make(map[int][]int)
+	var numbers []int
+	var free []int
+	for _, id := range ids {
+		number := registered[id]
+		if number <= 0 {
+			free = append(free, id)
+			continue
+		}
+		if len(byNumber[number]) == 0 {
+			numbers = append(numbers, number)
+		}
+		byNumber[number] = append(byNumber[number], id)
+		if len(byNumber[number]) > size {
+			return nil, fmt.Errorf("team %d has more than %d players", number, size)
+		}
+	}
+	if len(numbers)*size > len(ids) {
+		return nil, fmt.Errorf("%d players can't fill %d teams of %d", len(ids), len(numbers), size)
+	}
+	sort.Ints(numbers)
+
+	var teams [][]int
+	for _, number := range numbers {
+		team := byNumber[number]
+		for len(team) < size {
+			team = append(team, free[0])
+			free = free[1:]
+		}
+		teams = append(teams, team)
+	}
+	for len(free) > 0 {
+		teams = append(teams, free[:size])
+		free = free[size:]
+	}
+	return teams, nil
+}
@@ -0,0 +1,40 @@
+package tournament
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTeams_JoinOrder(t *testing.T) {
+	teams, err := Teams([]int{1, 2, 3, 4}, nil, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := [][]int{{1, 2}, {3, 4}}; !reflect.DeepEqual(teams, want) {
+		t.Errorf("expected %v, got %v", want, teams)
+	}
+}
+
+func TestTeams_Registered(t *testing.T) {
+	// 1 and 4 registered together, 6 alone in team 2, the rest fill in
+	registered := map[int]int{1: 1, 4: 1, 6: 2}
+	teams, err := Teams([]int{1, 2, 3, 4, 5, 6}, registered, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := [][]int{{1, 4}, {6, 2}, {3, 5}}; !reflect.DeepEqual(teams, want) {
+		t.Errorf("expected %v, got %v", want, teams)
+	}
+}
+
+func TestTeams_Refused(t *testing.T) {
+	if _, err := Teams([]int{1, 2, 3}, nil, 2); err == nil {
+		t.Error("expected error when players don't divide into teams")
+	}
+	if _, err := Teams([]int{1, 2, 3, 4}, map[int]int{1: 1, 2: 1, 3: 1}, 2); err == nil {
+		t.Error("expected error for a team with too many players")
+	}
+	if _, err := Teams([]int{1, 2, 3, 4}, map[int]int{1: 1, 2: 2, 3: 3}, 2); err == nil {
+		t.Error("expected error for more teams than the players can fill")
+	}
+}
@@ -0,0 +1,339 @@
+package tournament
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Slot values that are not entrant indexes
+const (
+	Bye = -1 // Nobody, the other entrant goes through
+	TBD = -2 // Waiting for an earlier match
+)
+
+// Format is the kind of elimination bracket
+type Format int
+
+const (
+	SingleElimination Format = iota // Out after the first loss
+	DoubleElimination               // Out after the second loss
+)
+
+// ParseFormat converts a bracket format name to a Format
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "single":
+		return SingleElimination, nil
+	case "double":
+		return DoubleElimination, nil
+	}
+	return SingleElimination, fmt.Errorf("unknown tournament format %q", name)
+}
+
+// String returns the format name
+func (f Format) String() string {
+	if f == DoubleElimination {
+		return "double"
+	}
+	return "single"
+}
+
+// Side is the part of the bracket a match belongs to
+type Side int
+
+const (
+	Winners Side = iota
+	Losers
+	Final // Grand final of a double elimination bracket
+)
+
+// String returns the side name
+func (s Side) String() string {
+	switch s {
+	case Losers:
+		return "losers"
+	case Final:
+		return "final"
+	}
+	return "winners"
+}
+
+// feed is where a match slot gets its entrant from
+type feed struct {
+	match int  // Earlier match, negative for a seeded slot
+	loser bool // Takes the loser of that match instead of the winner
+}
+
+// Match is one match of the bracket
+type Match struct {
+	Side     Side
+	Round    int
+	Entrants [2]int // Entrant indexes, Bye or TBD
+	Winner   int    // Entrant index, Bye or TBD until played
+	Walkover bool   // Decided by a bye, never played
+	feeds    [2]feed
+}
+
+// Loser returns the entrant that lost a decided match
+func (m Match) Loser() int {
+	if m.Winner == TBD {
+		return TBD
+	}
+	if m.Winner == m.Entrants[0] {
+		return m.Entrants[1]
+	}
+	return m.Entrants[0]
+}
+
+// Playable returns true if both entrants are known and the match is not
+// decided yet
+func (m Match) Playable() bool {
+	return m.Winner == TBD && m.Entrants[0] >= 0 && m.Entrants[1] >= 0
+}
+
+// Bracket runs an elimination tournament. Entrants are given best seed
+// first, and byes fill the bracket up to a power of two.
+type Bracket struct {
+	Format   Format
+	Entrants []string
+	Matches  []Match
+	reset    bool // The grand final reset match has been added
+}
+
+// New builds the bracket for the entrants, best seed first
+func New(format Format, entrants []string) (*Bracket, error) {
+	if len(entrants) < 2 {
+		return nil, errors.New("a tournament needs at least two entrants")
+	}
+
+	b := &Bracket{
+		Format:   format,
+		Entrants: append([]string(nil), entrants...),
+	}
+
+	size, rounds := 2, 1
+	for size < len(entrants) {
+		size *= 2
+		rounds++
+	}
+
+	// First round, top seeds get the byes
+	order := seedOrder(size)
+	var winners []int
+	for i := 0; i < size; i += 2 {
+		winners = append(winners, b.add(Match{
+			Side:     Winners,
+			Round:    1,
+			Entrants: [2]int{b.seed(order[i]), b.seed(order[i+1])},
+			feeds:    [2]feed{{match: -1}, {match: -1}},
+		}))
+	}
+	firstRound := winners
+
+	var losers []int
+	losersRound := 0
+	for round := 2; round <= rounds; round++ {
+		winners = b.addRound(Winners, round, winners, nil)
+		if format != DoubleElimination {
+			continue
+		}
+
+		if round == 2 {
+			// Losers of the first round play each other
+			losersRound++
+			losers = b.addRound(Losers, losersRound, firstRound, loserFeeds(firstRound))
+		}
+
+		// Losers bracket survivors meet the losers of this winners round,
+		// crossed over so first round opponents don't meet again straight away
+		losersRound++
+		dropped := make([]int, len(winners))
+		for i, m := range winners {
+			dropped[len(winners)-1-i] = m
+		}
+		var next []int
+		for i, m := range losers {
+			next = append(next, b.add(Match{
+				Side:     Losers,
+				Round:    losersRound,
+				Entrants: [2]int{TBD, TBD},
+				feeds:    [2]feed{{match: m}, {match: dropped[i], loser: true}},
+			}))
+		}
+		losers = next
+
+		if round < rounds {
+			losersRound++
+			losers = b.addRound(Losers, losersRound, losers, nil)
+		}
+	}
+
+	if format == DoubleElimination {
+		// With two entrants the losers bracket is just the first loser
+		lastChance := feed{match: winners[0], loser: true}
+		if len(losers) > 0 {
+			lastChance = feed{match: losers[0]}
+		}
+		b.add(Match{
+			Side:     Final,
+			Round:    1,
+			Entrants: [2]int{TBD, TBD},
+			feeds:    [2]feed{{match: winners[0]}, lastChance},
+		})
+	}
+
+	b.resolve()
+	return b, nil
+}
+
+// seed returns the entrant for a seed number, or a bye past the last entrant
+func (b *Bracket) seed(n int) int {
+	if n > len(b.Entrants) {
+		return Bye
+	}
+	return n - 1
+}
+
+// add appends a match and returns its index
+func (b *Bracket) add(m Match) int {
+	m.Winner = TBD
+	b.Matches = append(b.Matches, m)
+	return len(b.Matches) - 1
+}
+
+// addRound pairs up the matches of the previous round, taking the winners
+// unless other feeds are given
+func (b *Bracket) addRound(side Side, round int, previous []int, feeds []feed) []int {
+	if feeds == nil {
+		for _, m := range previous {
+			feeds = append(feeds, feed{match: m})
+		}
+	}
+
+	var matches []int
+	for i := 0; i+1 < len(feeds); i += 2 {
+		matches = append(matches, b.add(Match{
+			Side:     side,
+			Round:    round,
+			Entrants: [2]int{TBD, TBD},
+			feeds:    [2]feed{feeds[i], feeds[i+1]},
+		}))
+	}
+	return matches
+}
+
+// loserFeeds takes the losers of the given matches
+func loserFeeds(matches []int) []feed {
+	feeds := make([]feed, len(matches))
+	for i, m := range matches {
+		feeds[i] = feed{match: m, loser: true}
+	}
+	return feeds
+}
+
+// seedOrder returns the seed numbers in bracket order, so the top seeds
+// can only meet in the later rounds
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		n := len(order) * 2
+		next := make([]int, 0, n)
+		for _, s := range order {
+			next = append(next, s, n+1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+// resolve moves decided entrants into later matches and settles byes
+func (b *Bracket) resolve() {
+	for changed := true; changed; {
+		changed = false
+		for i := range b.Matches {
+			m := &b.Matches[i]
+			if m.Winner != TBD {
+				continue
+			}
+
+			for slot, f := range m.feeds {
+				if m.Entrants[slot] != TBD || f.match < 0 {
+					continue
+				}
+				from := b.Matches[f.match]
+				if from.Winner == TBD {
+					continue
+				}
+				if f.loser {
+					m.Entrants[slot] = from.Loser()
+				} else {
+					m.Entrants[slot] = from.Winner
+				}
+				changed = true
+			}
+
+			if m.Entrants[0] == Bye && m.Entrants[1] != TBD {
+				m.Winner, m.Walkover, changed = m.Entrants[1], true, true
+			} else if m.Entrants[1] == Bye && m.Entrants[0] != TBD {
+				m.Winner, m.Walkover, changed = m.Entrants[0], true, true
+			}
+		}
+	}
+}
+
+// Next returns the index of the next match to play, or -1 when the
+// tournament is over
+func (b *Bracket) Next() int {
+	for i, m := range b.Matches {
+		if m.Playable() {
+			return i
+		}
+	}
+	return -1
+}
+
+// Report records the winner of a match, by slot (0 or 1)
+func (b *Bracket) Report(match, slot int) error {
+	if match < 0 || match >= len(b.Matches) {
+		return fmt.Errorf("no match %d", match)
+	}
+	if slot != 0 && slot != 1 {
+		return fmt.Errorf("slot must be 0 or 1, got %d", slot)
+	}
+	m := &b.Matches[match]
+	if !m.Playable() {
+		return fmt.Errorf("match %d is not ready to play", match)
+	}
+
+	m.Winner = m.Entrants[slot]
+
+	// Beating the unbeaten finalist only levels it up, so they play again
+	if m.Side == Final && slot == 1 && !b.reset {
+		b.reset = true
+		b.add(Match{
+			Side:     Final,
+			Round:    2,
+			Entrants: m.Entrants,
+			feeds:    [2]feed{{match: -1}, {match: -1}},
+		})
+	}
+
+	b.resolve()
+	return nil
+}
+
+// Champion returns the winning entrant, or -1 while the tournament goes on
+func (b *Bracket) Champion() int {
+	if b.Next() >= 0 {
+		return -1
+	}
+	if winner := b.Matches[len(b.Matches)-1].Winner; winner >= 0 {
+		return winner
+	}
+	return -1
+}
+
+// Done returns true once the tournament has a champion
+func (b *Bracket) Done() bool {
+	return b.Champion() >= 0
+}
@@ -0,0 +1,178 @@
+package tournament
+
+import (
+	"fmt"
+	"testing"
+)
+
+func names(n int) []string {
+	entrants := make([]string, n)
+	for i := range entrants {
+		entrants[i] = fmt.Sprintf("P%d", i+1)
+	}
+	return entrants
+}
+
+// playOut reports every match until the end, the better seed always winning
+// unless upset says otherwise, and returns how many matches were played
+func playOut(t *testing.T, b *Bracket, upset func(m Match) bool) int {
+	t.Helper()
+	played := 0
+	for next := b.Next(); next >= 0; next = b.Next() {
+		m := b.Matches[next]
+		slot := 0
+		if m.Entrants[1] < m.Entrants[0] {
+			slot = 1
+		}
+		if upset != nil && upset(m) {
+			slot = 1 - slot
+		}
+		if err := b.Report(next, slot); err != nil {
+			t.Fatalf("report match %d: %v", next, err)
+		}
+		played++
+		if played > 100 {
+			t.Fatal("tournament never ends")
+		}
+	}
+	return played
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range []Format{SingleElimination, DoubleElimination} {
+		parsed, err := ParseFormat(f.String())
+		if err != nil || parsed != f {
+			t.Errorf("expected %q to parse to %d, got %d (%v)", f, f, parsed, err)
+		}
+	}
+	if _, err := ParseFormat("swiss"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestNew_NeedsTwoEntrants(t *testing.T) {
+	if _, err := New(SingleElimination, names(1)); err == nil {
+		t.Error("expected error for a single entrant")
+	}
+}
+
+func TestSingle_FirstRoundSeeding(t *testing.T) {
+	b, err := New(SingleElimination, names(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Matches) != 3 {
+		t.Fatalf("expected 3 matches, got %d", len(b.Matches))
+	}
+	if b.Matches[0].Entrants != [2]int{0, 3} || b.Matches[1].Entrants != [2]int{1, 2} {
+		t.Errorf("expected 1v4 and 2v3, got %v and %v", b.Matches[0].Entrants, b.Matches[1].Entrants)
+	}
+}
+
+func TestSingle_ByesGoToTopSeeds(t *testing.T) {
+	b, err := New(SingleElimination, names(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := b.Matches[0]
+	if !first.Walkover || first.Winner != 0 {
+		t.Errorf("expected top seed through on a bye, got %+v", first)
+	}
+	if next := b.Next(); next != 1 {
+		t.Errorf("expected the 2v3 match first, got %d", next)
+	}
+}
+
+func TestSingle_BestSeedWins(t *testing.T) {
+	for n := 2; n <= 9; n++ {
+		b, err := New(SingleElimination, names(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if played := playOut(t, b, nil); played != n-1 {
+			t.Errorf("%d entrants: expected %d matches played, got %d", n, n-1, played)
+		}
+		if b.Champion() != 0 {
+			t.Errorf("%d entrants: expected top seed as champion, got %d", n, b.Champion())
+		}
+	}
+}
+
+func TestDouble_OneLossIsNotOut(t *testing.T) {
+	b, err := New(DoubleElimination, names(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The top seed loses their first match and fights back to the title
+	upset := func(m Match) bool {
+		return m.Side == Winners && m.Round == 1 && (m.Entrants[0] == 0 || m.Entrants[1] == 0)
+	}
+	playOut(t, b, upset)
+
+	if b.Champion() != 0 {
+		t.Errorf("expected top seed to win through the losers bracket, got %d", b.Champion())
+	}
+	last := b.Matches[len(b.Matches)-1]
+	if last.Side != Final || last.Round != 2 {
+		t.Errorf("expected a grand final reset, last match was %s round %d", last.Side, last.Round)
+	}
+}
+
+func TestDouble_NoResetWhenUnbeatenWins(t *testing.T) {
+	b, err := New(DoubleElimination, names(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 8 entrants, each loses twice except the champion: 14 matches
+	if played := playOut(t, b, nil); played != 14 {
+		t.Errorf("expected 14 matches, got %d", played)
+	}
+	if b.Champion() != 0 {
+		t.Errorf("expected top seed as champion, got %d", b.Champion())
+	}
+}
+
+func TestDouble_EveryoneLosesTwice(t *testing.T) {
+	for n := 2; n <= 9; n++ {
+		b, err := New(DoubleElimination, names(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		playOut(t, b, nil)
+
+		losses := make([]int, n)
+		for _, m := range b.Matches {
+			if !m.Walkover && m.Winner >= 0 {
+				losses[m.Loser()]++
+			}
+		}
+		for i, l := range losses {
+			want := 2
+			if i == b.Champion() {
+				want = 0
+			}
+			if l != want {
+				t.Errorf("%d entrants: entrant %d lost %d times, expected %d", n, i, l, want)
+			}
+		}
+	}
+}
+
+func TestReport_RejectsUnreadyMatch(t *testing.T) {
+	b, err := New(SingleElimination, names(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Report(2, 0); err == nil {
+		t.Error("expected error reporting the final before the semi-finals")
+	}
+	if err := b.Report(0, 2); err == nil {
+		t.Error("expected error for a bad slot")
+	}
+	if err := b.Report(9, 0); err == nil {
+		t.Error("expected error for an unknown match")
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/gdamore/tcell/v2"
+)
+
+// bracketColumn is one round of the bracket, drawn as a column of matches
+type bracketColumn struct {
+	title   string
+	matches []protocol.BracketMatch
+}
+
+// RenderTournament displays the bracket between tournament matches, or the
+// champion once it is over
+func (r *Renderer) RenderTournament(state protocol.RematchState) {
+	r.screen.Clear()
+	screenW, screenH := r.screen.Size()
+	bracket := state.Bracket
+
+	if bracket.Champion != "" {
+		r.renderChampion(state, screenW, screenH)
+		r.screen.Show()
+		return
+	}
+
+	title := fmt.Sprintf("=== %s ELIMINATION TOURNAMENT ===", strings.ToUpper(bracket.Format))
+	r.screen.DrawText((screenW-len(title))/2, 1, title, tcell.StyleDefault.Bold(true).Foreground(tcell.ColorTeal))
+
+	// Who plays next
+	for _, m := range bracket.Matches {
+		if m.Next {
+			nextText := fmt.Sprintf("Next match: %s vs %s", m.Names[0], m.Names[1])
+			r.screen.DrawText((screenW-len(nextText))/2, 3, nextText, tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true))
+			break
+		}
+	}
+
+	y := 5
+	for _, side := range []string{"winners", "losers", "final"} {
+		columns := bracketColumns(bracket, side)
+		if len(columns) == 0 || y >= screenH-7 {
+			continue
+		}
+		y = r.renderBracketSide(columns, y, screenW, screenH-5) + 1
+	}
+
+	// Everyone readies up before the next match, as for a rematch
+	var waiting []string
+	for _, player := range state.Players {
+		if !player.Ready {
+			waiting = append(waiting, player.Name)
+		}
+	}
+	if len(waiting) > 0 {
+		waitingText := truncate("Waiting for: "+strings.Join(waiting, ", "), screenW-8)
+		r.screen.DrawText(4, screenH-5, waitingText, tcell.StyleDefault.Foreground(tcell.ColorGray))
+	}
+
+	var instructions string
+	switch {
+	case state.IsHost && state.AllReady:
+		instructions = "All players ready! Press ENTER to start the next match"
+	case state.IsHost:
+		instructions = "Waiting for all players to be ready..."
+	default:
+		instructions = "Press ENTER to mark yourself ready"
+	}
+	r.screen.DrawText(4, screenH-4, instructions, tcell.StyleDefault.Foreground(tcell.ColorGreen))
+	r.screen.DrawText(4, screenH-2, "Press 'q' to quit", tcell.StyleDefault.Foreground(tcell.ColorGray))
+
+	r.screen.Show()
+}
+
+// renderChampion draws the end of tournament screen
+func (r *Renderer) renderChampion(state protocol.RematchState, screenW, screenH int) {
+	title := "=== CHAMPION ==="
+	r.screen.DrawText((screenW-len(title))/2, 3, title, tcell.StyleDefault.Bold(true).Foreground(tcell.ColorYellow))
+
+	name := state.Bracket.Champion
+	boxW := max(len(name)+8, 24)
+	boxX := (screenW - boxW) / 2
+	centerY := screenH/2 - 2
+	r.screen.DrawBox(boxX, centerY-2, boxW, 5, tcell.StyleDefault.Foreground(tcell.ColorYellow))
+	r.screen.DrawText((screenW-len(name))/2, centerY, name, tcell.StyleDefault.Bold(true).Foreground(tcell.ColorWhite))
+
+	played := 0
+	for _, m := range state.Bracket.Matches {
+		if !m.Walkover && m.Winner >= 0 {
+			played++
+		}
+	}
+	summary := fmt.Sprintf("wins the %s elimination tournament after %d matches", state.Bracket.Format, played)
+	r.screen.DrawText((screenW-len(summary))/2, centerY+4, summary, tcell.StyleDefault.Foreground(tcell.ColorGray))
+
+	instructions := "Waiting for the host..."
+	if state.IsHost {
+		instructions = "Press ENTER to return to the lobby"
+	}
+	r.screen.DrawText((screenW-len(instructions))/2, screenH-4, instructions, tcell.StyleDefault.Foreground(tcell.ColorGreen))
+	r.screen.DrawText(4, screenH-2, "Press 'q' to quit", tcell.StyleDefault.Foreground(tcell.ColorGray))
+}
+
+// bracketColumns groups the matches of one side of the bracket by round.
+// Byes are left out, there is nothing to see there.
+func bracketColumns(bracket *protocol.BracketState, side string) []bracketColumn {
+	var columns []bracketColumn
+	round := 0
+	for _, m := range bracket.Matches {
+		if m.Side != side || (m.Walkover && (m.Names[0] == "" || m.Names[1] == "")) {
+			continue
+		}
+		if len(columns) == 0 || m.Round != round {
+			round = m.Round
+			columns = append(columns, bracketColumn{title: roundTitle(side, round)})
+		}
+		columns[len(columns)-1].matches = append(columns[len(columns)-1].matches, m)
+	}
+
+	// The last winners round of a single elimination is the final
+	if side == "winners" && bracket.Format == "single" && len(columns) > 0 {
+		columns[len(columns)-1].title = "Final"
+		if len(columns) > 1 {
+			columns[len(columns)-2].title = "Semi-finals"
+		}
+	}
+	return columns
+}
+
+// roundTitle names a round of the bracket
+func roundTitle(side string, round int) string {
+	switch side {
+	case "losers":
+		return fmt.Sprintf("Losers %d", round)
+	case "final":
+		if round > 1 {
+			return "Final reset"
+		}
+		return "Grand final"
+	}
+	return fmt.Sprintf("Round %d", round)
+}
+
+// renderBracketSide draws the rounds side by side and returns the first
+// free row below them
+func (r *Renderer) renderBracketSide(columns []bracketColumn, y, screenW, maxY int) int {
+	colW := min(22, (screenW-8)/len(columns))
+	bottom := y
+	for i, column := range columns {
+		x := 4 + i*colW
+		r.screen.DrawText(x, y, truncate(column.title, colW-2), tcell.StyleDefault.Foreground(tcell.ColorGray).Bold(true))
+
+		rowY := y + 1
+		for _, m := range column.matches {
+			if rowY+1 >= maxY {
+				break
+			}
+			for slot, name := range m.Names {
+				style, marker := bracketNameStyle(m, slot)
+				if name == "" {
+					name = "?"
+				}
+				r.screen.DrawText(x, rowY+slot, marker+truncate(name, colW-4), style)
+			}
+			rowY += 3
+		}
+		bottom = max(bottom, rowY)
+	}
+	return bottom
+}
+
+// bracketNameStyle highlights winners and the next match
+func bracketNameStyle(m protocol.BracketMatch, slot int) (tcell.Style, string) {
+	switch {
+	case m.Next:
+		return tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true), "> "
+	case m.Winner == slot:
+		return tcell.StyleDefault.Foreground(tcell.ColorGreen), "  "
+	case m.Winner >= 0:
+		return tcell.StyleDefault.Foreground(tcell.ColorGray), "  "
+	}
+	return tcell.StyleDefault, "  "
+}
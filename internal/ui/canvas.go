@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Resolution is how finely balls and paddles are drawn
+type Resolution int
+
+const (
+	CellResolution      Resolution = iota // One pixel per cell
+	HalfBlockResolution                   // Two pixels per cell, one above the other
+	BrailleResolution                     // Two by four braille dots per cell
+)
+
+// Glyphs used to draw pixels finer than a cell
+const (
+	UpperHalfChar = '\u2580' // ▀
+	LowerHalfChar = '\u2584' // ▄
+	BrailleBase   = '\u2800' // Empty braille pattern
+)
+
+// brailleDots are the bits of each dot in a braille pattern, by row and column
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// ParseResolution converts a resolution name to a Resolution
+func ParseResolution(name string) (Resolution, error) {
+	switch name {
+	case "cell":
+		return CellResolution, nil
+	case "half":
+		return HalfBlockResolution, nil
+	case "braille":
+		return BrailleResolution, nil
+	}
+	return CellResolution, fmt.Errorf("unknown resolution %q", name)
+}
+
+// String returns the resolution name
+func (res Resolution) String() string {
+	switch res {
+	case HalfBlockResolution:
+		return "half"
+	case BrailleResolution:
+		return "braille"
+	}
+	return "cell"
+}
+
+// subcells returns how many pixels a cell holds across and down
+func (res Resolution) subcells() (int, int) {
+	switch res {
+	case HalfBlockResolution:
+		return 1, 2
+	case BrailleResolution:
+		return 2, 4
+	}
+	return 1, 1
+}
+
+// glyph returns a character the terminal must be able to show for the
+// resolution to work
+func (res Resolution) glyph() rune {
+	switch res {
+	case HalfBlockResolution:
+		return UpperHalfChar
+	case BrailleResolution:
+		return BrailleBase + 0xFF // All eight dots
+	}
+	return PaddleChar
+}
+
+// canvas collects pixels finer than a cell over the court, then draws them
+// as half blocks or braille patterns
+type canvas struct {
+	res           Resolution
+	v             courtView
+	subW, subH    int
+	width, height int           // Size in pixels
+	pixels        []tcell.Color // ColorDefault where nothing is drawn
+}
+
+// newCanvas creates an empty canvas covering the court
+func newCanvas(res Resolution, v courtView) *canvas {
+	c := newGrid(res, v)
+	c.pixels = make([]tcell.Color, c.width*c.height)
+	return c
+}
+
+// newGrid creates a canvas without pixels, only good for working out where
+// things go
+func newGrid(res Resolution, v courtView) *canvas {
+	subW, subH := res.subcells()
+	return &canvas{
+		res:    res,
+		v:      v,
+		subW:   subW,
+		subH:   subH,
+		width:  v.width * subW,
+		height: v.height * subH,
+	}
+}
+
+// pos returns the pixel position of a court position. Like courtView, a
+// court position lands in the middle of its cell.
+func (c *canvas) pos(courtX, courtY float64) (float64, float64) {
+	return (courtX*c.v.scale + 0.5) * float64(c.subW), (courtY*c.v.scale + 0.5) * float64(c.subH)
+}
+
+// fill colors the pixels from x0,y0 up to but not including x1,y1
+func (c *canvas) fill(x0, y0, x1, y1 int, color tcell.Color) {
+	for y := max(y0, 0); y < min(y1, c.height); y++ {
+		for x := max(x0, 0); x < min(x1, c.width); x++ {
+			c.pixels[y*c.width+x] = color
+		}
+	}
+}
+
+// ball draws a ball about as wide as it is tall, cells being twice as tall
+// as they are wide
+func (c *canvas) ball(ball protocol.BallState, color tcell.Color) {
+	w, h := c.subW, max(1, c.subH/2)
+	px, py := c.pos(ball.X, ball.Y)
+	x0 := int(math.Round(px - float64(w)/2))
+	y0 := int(math.Round(py - float64(h)/2))
+	c.fill(x0, y0, x0+w, y0+h, color)
+}
+
+// paddle draws a paddle a whole cell thick, with its ends placed to the pixel
+func (c *canvas) paddle(paddle protocol.PaddleState, color tcell.Color) {
+	x0, y0, x1, y1 := c.paddleRect(paddle)
+	c.fill(x0, y0, x1, y1, color)
+}
+
+// paddleRect returns the pixels a paddle covers, from x0,y0 up to but not
+// including x1,y1
+func (c *canvas) paddleRect(paddle protocol.PaddleState) (int, int, int, int) {
+	half := float64(paddle.Height) / 2
+	if paddle.Horizontal {
+		_, py := c.pos(0, float64(paddle.Column))
+		row := int(math.Floor(py/float64(c.subH))) * c.subH
+		left, _ := c.pos(paddle.Y-half, 0)
+		right, _ := c.pos(paddle.Y+half, 0)
+		x0 := int(math.Round(left))
+		return x0, row, max(x0+1, int(math.Round(right))), row + c.subH
+	}
+
+	px, _ := c.pos(float64(paddle.Column), 0)
+	col := int(math.Floor(px/float64(c.subW))) * c.subW
+	_, top := c.pos(0, paddle.Y-half)
+	_, bottom := c.pos(0, paddle.Y+half)
+	y0 := int(math.Round(top))
+	return col, y0, col + c.subW, max(y0+1, int(math.Round(bottom)))
+}
+
+// cellBox returns the screen cells a pixel area touches: first and last
+// column and row
+func (c *canvas) cellBox(x0, y0, x1, y1 int) (int, int, int, int) {
+	cell := func(p, sub int) int {
+		return int(math.Floor(float64(p) / float64(sub)))
+	}
+	return c.v.left + cell(x0, c.subW), c.v.left + cell(x1-1, c.subW),
+		c.v.top + cell(y0, c.subH), c.v.top + cell(y1-1, c.subH)
+}
+
+// draw puts the canvas on screen, leaving cells without pixels untouched
+func (c *canvas) draw(screen *Screen) {
+	for cy := 0; cy < c.v.height; cy++ {
+		for cx := 0; cx < c.v.width; cx++ {
+			var style tcell.Style
+			var glyph rune
+			if c.res == BrailleResolution {
+				style, glyph = c.brailleCell(cx, cy)
+			} else {
+				style, glyph = c.halfBlockCell(cx, cy)
+			}
+			if glyph != 0 {
+				screen.SetCell(c.v.left+cx, c.v.top+cy, style, glyph)
+			}
+		}
+	}
+}
+
+// halfBlockCell returns the glyph for a cell of two stacked pixels, each in
+// its own color, or 0 if both are empty
+func (c *canvas) halfBlockCell(cx, cy int) (tcell.Style, rune) {
+	top := c.pixels[cy*2*c.width+cx]
+	bottom := c.pixels[(cy*2+1)*c.width+cx]
+	style := tcell.StyleDefault
+	switch {
+	case top == tcell.ColorDefault && bottom == tcell.ColorDefault:
+		return style, 0
+	case top == bottom:
+		return style.Foreground(top), PaddleChar
+	case bottom == tcell.ColorDefault:
+		return style.Foreground(top), UpperHalfChar
+	case top == tcell.ColorDefault:
+		return style.Foreground(bottom), LowerHalfChar
+	}
+	return style.Foreground(top).Background(bottom), UpperHalfChar
+}
+
+// brailleCell returns the braille pattern for a cell, or 0 if it has no dots.
+// A cell can only show one color, so dots of different colors share the
+// color of the last one.
+func (c *canvas) brailleCell(cx, cy int) (tcell.Style, rune) {
+	var dots rune
+	color := tcell.ColorDefault
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 2; col++ {
+			pixel := c.pixels[(cy*4+row)*c.width+cx*2+col]
+			if pixel != tcell.ColorDefault {
+				dots |= brailleDots[row][col]
+				color = pixel
+			}
+		}
+	}
+	if dots == 0 {
+		return tcell.StyleDefault, 0
+	}
+	return tcell.StyleDefault.Foreground(color), BrailleBase + dots
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestParseResolution(t *testing.T) {
+	for _, res := range []Resolution{CellResolution, HalfBlockResolution, BrailleResolution} {
+		parsed, err := ParseResolution(res.String())
+		if err != nil || parsed != res {
+			t.Errorf("expected %q to parse to %d, got %d (%v)", res, res, parsed, err)
+		}
+	}
+	if _, err := ParseResolution("pixel"); err == nil {
+		t.Error("expected error for unknown resolution")
+	}
+}
+
+func TestCanvas_HalfBlockBall(t *testing.T) {
+	v := newCourtView(40, 20, 40, 22)
+
+	// A ball in the top half of a cell, then in the bottom half
+	c := newCanvas(HalfBlockResolution, v)
+	c.ball(protocol.BallState{X: 10, Y: 4.8}, tcell.ColorWhite)
+	if _, glyph := c.halfBlockCell(10, 5); glyph != UpperHalfChar {
+		t.Errorf("expected an upper half block, got %q", glyph)
+	}
+
+	c = newCanvas(HalfBlockResolution, v)
+	c.ball(protocol.BallState{X: 10, Y: 5.2}, tcell.ColorWhite)
+	if _, glyph := c.halfBlockCell(10, 5); glyph != LowerHalfChar {
+		t.Errorf("expected a lower half block, got %q", glyph)
+	}
+}
+
+func TestCanvas_HalfBlockTwoColors(t *testing.T) {
+	c := newCanvas(HalfBlockResolution, newCourtView(40, 20, 40, 22))
+	c.fill(3, 4, 4, 5, tcell.ColorRed)
+	c.fill(3, 5, 4, 6, tcell.ColorBlue)
+
+	style, glyph := c.halfBlockCell(3, 2)
+	fg, bg, _ := style.Decompose()
+	if glyph != UpperHalfChar || fg != tcell.ColorRed || bg != tcell.ColorBlue {
+		t.Errorf("expected red over blue, got %q in %v on %v", glyph, fg, bg)
+	}
+}
+
+func TestCanvas_BraillePaddle(t *testing.T) {
+	c := newCanvas(BrailleResolution, newCourtView(40, 20, 40, 22))
+
+	// A paddle from the middle of row 2 to the middle of row 4
+	c.paddle(protocol.PaddleState{Column: 1, Y: 3, Height: 2}, tcell.ColorGreen)
+
+	want := map[int]rune{1: 0, 2: BrailleBase + 0xC0 + 0x24, 3: BrailleBase + 0xFF, 4: BrailleBase + 0x1B, 5: 0}
+	for row, dots := range want {
+		_, glyph := c.brailleCell(1, row)
+		if glyph != dots {
+			t.Errorf("row %d: expected %q, got %q", row, dots, glyph)
+		}
+	}
+}
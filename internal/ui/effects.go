@@ -0,0 +1,296 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Effect lengths, in ticks
+const (
+	TrailLength    = 6  // Positions kept behind a ball at serve speed
+	FlashTicks     = 8  // A paddle lights up after a hit at serve speed
+	RippleTicks    = 12 // A bounce off a wall or obstacle
+	ExplosionTicks = 30 // A goal
+)
+
+const (
+	effectBaseSpeed = 0.28 // Default serve speed, at which effects are normal size
+	maxTrailStep    = 4.0  // Farther than any ball moves in a tick
+)
+
+// burstKind is an effect that spreads out from a point
+type burstKind int
+
+const (
+	rippleBurst burstKind = iota
+	explosionBurst
+)
+
+// burst is a ripple or explosion in progress
+type burst struct {
+	kind      burstKind
+	x, y      float64 // Court position
+	start     int     // Tick it started
+	intensity float64
+}
+
+// Effects follows the game state from frame to frame and keeps what's needed
+// to draw trails, hit flashes, ripples and explosions
+type Effects struct {
+	prev    protocol.GameState
+	trails  [][]protocol.BallState // Recent positions of each ball, oldest first
+	flashes map[string]int         // Paddle ID to the tick its hit flash ends
+	bursts  []burst
+}
+
+// NewEffects creates an effects tracker with nothing going on
+func NewEffects() *Effects {
+	return &Effects{flashes: make(map[string]int)}
+}
+
+// intensity returns how big effects of a ball are, by its speed
+func intensity(ball protocol.BallState) float64 {
+	speed := math.Hypot(ball.VX, ball.VY)
+	return math.Max(0.5, math.Min(2.5, speed/effectBaseSpeed))
+}
+
+// Update compares a new game state with the last one and starts effects for
+// what happened in between
+func (e *Effects) Update(state protocol.GameState) {
+	prev := e.prev
+	if state.Tick == prev.Tick {
+		return
+	}
+	e.prev = state
+
+	// A new match starts counting ticks again
+	if state.Tick < prev.Tick || prev.Tick == 0 {
+		e.trails = nil
+		e.flashes = make(map[string]int)
+		e.bursts = nil
+		return
+	}
+
+	// Match each ball with the same ball in the previous frame. When a ball
+	// leaves the court the list shifts, so start the trails over.
+	balls := append([]protocol.BallState{state.Ball}, state.ExtraBalls...)
+	prevBalls := append([]protocol.BallState{prev.Ball}, prev.ExtraBalls...)
+	if len(balls) != len(prevBalls) || len(e.trails) != len(balls) {
+		e.trails = make([][]protocol.BallState, len(balls))
+		prevBalls = nil
+	}
+
+	// After a goal the balls are served again, that is no bounce
+	if e.scored(state, prev) {
+		e.explode(state, prev)
+		prevBalls = nil
+	}
+
+	for i, ball := range balls {
+		// A ball served again jumps back to the middle, leave the old trail behind
+		jumped := false
+		if trail := e.trails[i]; len(trail) > 0 {
+			last := trail[len(trail)-1]
+			if math.Hypot(ball.X-last.X, ball.Y-last.Y) > maxTrailStep {
+				e.trails[i] = nil
+				jumped = true
+			}
+		}
+		e.trails[i] = append(e.trails[i], ball)
+		if keep := trailLength(ball); len(e.trails[i]) > keep {
+			e.trails[i] = e.trails[i][len(e.trails[i])-keep:]
+		}
+		if prevBalls == nil || jumped {
+			continue
+		}
+
+		prevBall := prevBalls[i]
+		bouncedX := prevBall.VX*ball.VX < 0
+		bouncedY := prevBall.VY*ball.VY < 0
+		if !bouncedX && !bouncedY {
+			continue
+		}
+		if id, ok := hitPaddle(state.Paddles, ball); ok {
+			// Harder hits flash longer
+			e.flashes[id] = state.Tick + int(math.Round(FlashTicks*intensity(ball)))
+			continue
+		}
+		e.bursts = append(e.bursts, burst{
+			kind:      rippleBurst,
+			x:         ball.X,
+			y:         ball.Y,
+			start:     state.Tick,
+			intensity: intensity(ball),
+		})
+	}
+
+	// Forget what has faded
+	bursts := e.bursts[:0]
+	for _, b := range e.bursts {
+		if state.Tick-b.start < b.duration() {
+			bursts = append(bursts, b)
+		}
+	}
+	e.bursts = bursts
+	for id, end := range e.flashes {
+		if state.Tick >= end {
+			delete(e.flashes, id)
+		}
+	}
+}
+
+// scored returns true if a goal went in since the previous state
+func (e *Effects) scored(state, prev protocol.GameState) bool {
+	if state.LeftScore > prev.LeftScore || state.RightScore > prev.RightScore {
+		return true
+	}
+	if state.Match.LeftSets > prev.Match.LeftSets || state.Match.RightSets > prev.Match.RightSets {
+		return true
+	}
+	for i, side := range state.Sides {
+		if i < len(prev.Sides) && side.Conceded > prev.Sides[i].Conceded {
+			return true
+		}
+	}
+	return false
+}
+
+// explode starts an explosion where the goal went in: the last position of
+// the ball closest to an edge of the court
+func (e *Effects) explode(state, prev protocol.GameState) {
+	width, height := float64(prev.CourtWidth), float64(prev.CourtHeight)
+	var scorer protocol.BallState
+	closest := math.Inf(1)
+	for _, ball := range append([]protocol.BallState{prev.Ball}, prev.ExtraBalls...) {
+		edge := math.Min(math.Min(ball.X, width-ball.X), math.Min(ball.Y, height-ball.Y))
+		if edge < closest {
+			scorer, closest = ball, edge
+		}
+	}
+
+	e.bursts = append(e.bursts, burst{
+		kind:      explosionBurst,
+		x:         math.Max(0, math.Min(width-1, scorer.X)),
+		y:         math.Max(0, math.Min(height-1, scorer.Y)),
+		start:     state.Tick,
+		intensity: intensity(scorer),
+	})
+}
+
+// trailLength returns how many positions to keep behind a ball, more the
+// faster it goes
+func trailLength(ball protocol.BallState) int {
+	return max(2, int(math.Round(TrailLength*intensity(ball))))
+}
+
+// hitPaddle returns the paddle the ball bounced off, if it is next to one
+func hitPaddle(paddles []protocol.PaddleState, ball protocol.BallState) (string, bool) {
+	const reach = 2.0
+	for _, paddle := range paddles {
+		across, along := ball.X, ball.Y
+		if paddle.Horizontal {
+			across, along = ball.Y, ball.X
+		}
+		half := float64(paddle.Height)/2 + reach
+		if math.Abs(across-float64(paddle.Column)) <= reach && math.Abs(along-paddle.Y) <= half {
+			return paddle.ID, true
+		}
+	}
+	return "", false
+}
+
+// duration returns how many ticks the burst lasts
+func (b burst) duration() int {
+	if b.kind == explosionBurst {
+		return ExplosionTicks
+	}
+	return RippleTicks
+}
+
+// flashing returns true if the paddle hit the ball a moment ago
+func (e *Effects) flashing(id string) bool {
+	end, ok := e.flashes[id]
+	return ok && e.prev.Tick < end
+}
+
+// renderTrails draws the fading positions behind each ball
+func (r *Renderer) renderTrails(v courtView) {
+	for _, trail := range r.effects.trails {
+		if len(trail) < 2 {
+			continue
+		}
+		ball := trail[len(trail)-1]
+		ballX, ballY := v.x(ball.X), v.y(ball.Y)
+		for age := 1; age < len(trail); age++ {
+			pos := trail[len(trail)-1-age]
+			x, y := v.x(pos.X), v.y(pos.Y)
+			if (x == ballX && y == ballY) || !v.contains(x, y) {
+				continue
+			}
+			fade := min(len(glyphs.Trail)-1, age*len(glyphs.Trail)/len(trail))
+			r.screen.SetCell(x, y, fadeStyle(fade), glyphs.Trail[fade])
+		}
+	}
+}
+
+// fadeStyle returns a dimmer gray for each step of a fading trail
+func fadeStyle(step int) tcell.Style {
+	colors := []tcell.Color{tcell.ColorSilver, tcell.ColorGray, tcell.ColorDarkGray}
+	return tcell.StyleDefault.Foreground(colors[min(step, len(colors)-1)])
+}
+
+// renderBursts draws the ripples, or the explosions
+func (r *Renderer) renderBursts(v courtView, kind burstKind) {
+	for _, b := range r.effects.bursts {
+		if b.kind != kind {
+			continue
+		}
+		age := r.effects.prev.Tick - b.start
+		progress := float64(age) / float64(b.duration())
+		if progress < 0 || progress >= 1 {
+			continue
+		}
+		if kind == explosionBurst {
+			r.renderExplosion(v, b, progress)
+		} else {
+			r.renderRipple(v, b, progress)
+		}
+	}
+}
+
+// renderRipple draws a ring spreading from a bounce
+func (r *Renderer) renderRipple(v courtView, b burst, progress float64) {
+	radius := (0.5 + 2*progress*b.intensity) * v.scale
+	colors := []tcell.Color{tcell.ColorAqua, tcell.ColorTeal, tcell.ColorDarkCyan}
+	style := tcell.StyleDefault.Foreground(colors[int(progress*float64(len(colors)))])
+	r.ring(v, b, radius, 12, style, glyphs.Ripple)
+}
+
+// renderExplosion draws sparks flying out from where a goal went in
+func (r *Renderer) renderExplosion(v courtView, b burst, progress float64) {
+	radius := (0.5 + 5*progress*b.intensity) * v.scale
+	colors := []tcell.Color{tcell.ColorWhite, tcell.ColorYellow, tcell.ColorOrange, tcell.ColorRed, tcell.ColorMaroon}
+	step := int(progress * float64(len(colors)))
+	style := tcell.StyleDefault.Foreground(colors[step]).Bold(step < 2)
+	spark := glyphs.Sparks[min(step, len(glyphs.Sparks)-1)]
+	r.ring(v, b, radius, 16, style, spark)
+
+	// A second, slower ring fills the middle
+	r.ring(v, b, radius/2, 8, style, spark)
+}
+
+// ring draws points on a circle around a burst. Cells are about twice as
+// tall as they are wide, so the circle is twice as wide in cells.
+func (r *Renderer) ring(v courtView, b burst, radius float64, points int, style tcell.Style, glyph rune) {
+	centerX, centerY := v.x(b.x), v.y(b.y)
+	for i := 0; i < points; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(points)
+		x := centerX + int(math.Round(2*radius*math.Cos(angle)))
+		y := centerY + int(math.Round(radius*math.Sin(angle)))
+		if v.contains(x, y) {
+			r.screen.SetCell(x, y, style, glyph)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+// effectsAt feeds a run of states to a new tracker, one tick apart
+func effectsAt(states ...protocol.GameState) *Effects {
+	e := NewEffects()
+	for i, state := range states {
+		state.Tick = i + 1
+		state.CourtWidth, state.CourtHeight = 80, 24
+		e.Update(state)
+	}
+	return e
+}
+
+func TestEffects_TrailGrowsWithSpeed(t *testing.T) {
+	var slow, fast []protocol.GameState
+	for i := 0; i < 30; i++ {
+		slow = append(slow, protocol.GameState{Ball: protocol.BallState{X: 10 + float64(i)*0.28, Y: 12, VX: 0.28}})
+		fast = append(fast, protocol.GameState{Ball: protocol.BallState{X: 10 + float64(i)*0.56, Y: 12, VX: 0.56}})
+	}
+	if got := len(effectsAt(slow...).trails[0]); got != TrailLength {
+		t.Errorf("expected a trail of %d at serve speed, got %d", TrailLength, got)
+	}
+	if got := len(effectsAt(fast...).trails[0]); got != 2*TrailLength {
+		t.Errorf("expected a trail of %d at double speed, got %d", 2*TrailLength, got)
+	}
+}
+
+func TestEffects_PaddleHitFlashes(t *testing.T) {
+	paddles := []protocol.PaddleState{{ID: "1", Column: 2, Y: 12, Height: 4}}
+	e := effectsAt(
+		protocol.GameState{Ball: protocol.BallState{X: 4, Y: 12, VX: -0.28}, Paddles: paddles},
+		protocol.GameState{Ball: protocol.BallState{X: 3.5, Y: 12, VX: -0.28}, Paddles: paddles},
+		protocol.GameState{Ball: protocol.BallState{X: 3.8, Y: 12, VX: 0.28}, Paddles: paddles},
+	)
+	if !e.flashing("1") {
+		t.Error("expected the paddle to flash after a hit")
+	}
+	if len(e.bursts) != 0 {
+		t.Errorf("expected no ripple for a paddle hit, got %d", len(e.bursts))
+	}
+}
+
+func TestEffects_WallBounceRipples(t *testing.T) {
+	e := effectsAt(
+		protocol.GameState{Ball: protocol.BallState{X: 40, Y: 1, VX: 0.2, VY: -0.2}},
+		protocol.GameState{Ball: protocol.BallState{X: 40.2, Y: 0.8, VX: 0.2, VY: -0.2}},
+		protocol.GameState{Ball: protocol.BallState{X: 40.4, Y: 1, VX: 0.2, VY: 0.2}},
+	)
+	if len(e.bursts) != 1 || e.bursts[0].kind != rippleBurst {
+		t.Fatalf("expected one ripple, got %+v", e.bursts)
+	}
+}
+
+func TestEffects_GoalExplodes(t *testing.T) {
+	e := effectsAt(
+		protocol.GameState{Ball: protocol.BallState{X: 1, Y: 5, VX: -0.28}},
+		protocol.GameState{Ball: protocol.BallState{X: 0.7, Y: 5, VX: -0.28}},
+		protocol.GameState{Ball: protocol.BallState{X: 40, Y: 12, VX: 0.28}, RightScore: 1},
+	)
+	if len(e.bursts) != 1 || e.bursts[0].kind != explosionBurst {
+		t.Fatalf("expected one explosion, got %+v", e.bursts)
+	}
+	if b := e.bursts[0]; b.x != 0.7 || b.y != 5 {
+		t.Errorf("expected the explosion where the ball went out, got %g,%g", b.x, b.y)
+	}
+	if len(e.trails[0]) != 1 {
+		t.Errorf("expected the trail to start over after the serve, got %d positions", len(e.trails[0]))
+	}
+}
+
+func TestEffects_NewMatchClears(t *testing.T) {
+	e := effectsAt(
+		protocol.GameState{Ball: protocol.BallState{X: 1, Y: 5, VX: -0.28}},
+		protocol.GameState{Ball: protocol.BallState{X: 40, Y: 12}, LeftScore: 1},
+	)
+	e.Update(protocol.GameState{Tick: 1})
+	if len(e.bursts) != 0 || e.trails != nil {
+		t.Errorf("expected effects cleared for a new match, got %+v", e)
+	}
+}
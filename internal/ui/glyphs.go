@@ -0,0 +1,137 @@
+package ui
+
+import "github.com/diegok/pixpong/internal/protocol"
+
+// Glyphs are the characters every screen is drawn with
+type Glyphs struct {
+	ASCII     bool // Plain ASCII only, which also rules out finer resolutions
+	Ball      rune
+	Paddle    rune
+	Shield    rune // Goal shield on a left or right edge
+	Block     rune
+	Bumper    rune
+	GoalWall  rune // Closed goal line on a left or right edge
+	SideWall  rune // Wall or shield on a top or bottom edge
+	Letterbox rune
+	PowerUps  map[protocol.PowerUpKind]rune
+	Trail     []rune // Fading trail behind a ball, newest first
+	Ripple    rune
+	Sparks    []rune // Goal explosion, from fresh to fading
+
+	// Arrows pointing at the player's own paddle
+	MarkerLeft, MarkerRight, MarkerUp, MarkerDown rune
+
+	// Message box borders
+	BoxTopLeft, BoxTopRight, BoxBottomLeft, BoxBottomRight rune
+	BoxHorizontal, BoxVertical                             rune
+
+	Degree string // Unit after the bounce angle
+}
+
+// UnicodeGlyphs returns the original glyphs
+func UnicodeGlyphs() Glyphs {
+	return Glyphs{
+		Ball:      BallChar,
+		Paddle:    PaddleChar,
+		Shield:    ShieldChar,
+		Block:     BlockChar,
+		Bumper:    BumperChar,
+		GoalWall:  GoalWall,
+		SideWall:  SideWall,
+		Letterbox: LetterboxChar,
+		PowerUps:  PowerUpChars,
+		Trail:     []rune{'\u2022', '\u2219', '\u00B7'}, // • ∙ ·
+		Ripple:    '\u00B7',                             // ·
+		Sparks:    []rune{'*', '*', '+', '+', '\u00B7'}, // ·
+
+		MarkerLeft:  MarkerLeft,
+		MarkerRight: MarkerRight,
+		MarkerUp:    MarkerUp,
+		MarkerDown:  MarkerDown,
+
+		BoxTopLeft:     '\u250C', // ┌
+		BoxTopRight:    '\u2510', // ┐
+		BoxBottomLeft:  '\u2514', // └
+		BoxBottomRight: '\u2518', // ┘
+		BoxHorizontal:  '\u2500', // ─
+		BoxVertical:    '\u2502', // │
+
+		Degree: "\u00B0", // °
+	}
+}
+
+// ASCIIGlyphs returns glyphs for terminals without Unicode, picked so what
+// is on the court still looks like what it is
+func ASCIIGlyphs() Glyphs {
+	return Glyphs{
+		ASCII:     true,
+		Ball:      'O',
+		Paddle:    '#',
+		Shield:    '!',
+		Block:     'X',
+		Bumper:    '@',
+		GoalWall:  '|',
+		SideWall:  '=',
+		Letterbox: '.',
+		PowerUps: map[protocol.PowerUpKind]rune{
+			protocol.PowerUpGrow:      '+',
+			protocol.PowerUpShrink:    '-',
+			protocol.PowerUpSpeed:     '>',
+			protocol.PowerUpReverse:   '~',
+			protocol.PowerUpExtraBall: '8',
+			protocol.PowerUpShield:    '[',
+		},
+		Trail:  []rune{'o', '.', '.'},
+		Ripple: '.',
+		Sparks: []rune{'*', '*', '+', '+', '.'},
+
+		MarkerLeft:  '<',
+		MarkerRight: '>',
+		MarkerUp:    '^',
+		MarkerDown:  'v',
+
+		BoxTopLeft:     '+',
+		BoxTopRight:    '+',
+		BoxBottomLeft:  '+',
+		BoxBottomRight: '+',
+		BoxHorizontal:  '-',
+		BoxVertical:    '|',
+
+		Degree: " deg",
+	}
+}
+
+// glyphs are the glyphs in use
+var glyphs = UnicodeGlyphs()
+
+// UseGlyphs switches every screen to a glyph set. Call it before
+// SetResolution, which keeps ASCII glyphs to whole cells.
+func UseGlyphs(g Glyphs) {
+	glyphs = g
+}
+
+// CanDisplay returns true if the terminal can show every glyph in the set
+func (g Glyphs) CanDisplay(s *Screen) bool {
+	for _, glyph := range g.runes() {
+		if !s.CanDisplay(glyph) {
+			return false
+		}
+	}
+	return true
+}
+
+// runes returns every glyph in the set
+func (g Glyphs) runes() []rune {
+	runes := []rune{
+		g.Ball, g.Paddle, g.Shield, g.Block, g.Bumper, g.GoalWall, g.SideWall, g.Letterbox, g.Ripple,
+		g.MarkerLeft, g.MarkerRight, g.MarkerUp, g.MarkerDown,
+		g.BoxTopLeft, g.BoxTopRight, g.BoxBottomLeft, g.BoxBottomRight, g.BoxHorizontal, g.BoxVertical,
+	}
+	runes = append(runes, g.Trail...)
+	runes = append(runes, g.Sparks...)
+	runes = append(runes, []rune(g.Degree)...)
+	for _, glyph := range g.PowerUps {
+		runes = append(runes, glyph)
+	}
+	return runes
+}
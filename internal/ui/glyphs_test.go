@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newTestScreen returns a simulated terminal using the given character set
+func newTestScreen(t *testing.T, charset string) *Screen {
+	t.Helper()
+	sim := tcell.NewSimulationScreen(charset)
+	if err := sim.Init(); err != nil {
+		t.Fatalf("failed to start simulated screen: %v", err)
+	}
+	t.Cleanup(sim.Fini)
+	return NewScreen(sim)
+}
+
+func TestASCIIGlyphs_PlainASCII(t *testing.T) {
+	g := ASCIIGlyphs()
+	for _, glyph := range g.runes() {
+		if glyph > 0x7E || glyph < 0x20 {
+			t.Errorf("expected printable ASCII, got %q", glyph)
+		}
+	}
+	for kind := range PowerUpChars {
+		if _, ok := g.PowerUps[kind]; !ok {
+			t.Errorf("expected an ASCII glyph for power-up %v", kind)
+		}
+	}
+	if len(g.Trail) != len(UnicodeGlyphs().Trail) || len(g.Sparks) != len(UnicodeGlyphs().Sparks) {
+		t.Error("expected as many trail and spark steps as the Unicode glyphs")
+	}
+}
+
+func TestGlyphs_CanDisplay(t *testing.T) {
+	ascii := newTestScreen(t, "US-ASCII")
+	if UnicodeGlyphs().CanDisplay(ascii) {
+		t.Error("expected an ASCII terminal not to show the Unicode glyphs")
+	}
+	if !ASCIIGlyphs().CanDisplay(ascii) {
+		t.Error("expected an ASCII terminal to show the ASCII glyphs")
+	}
+	if !UnicodeGlyphs().CanDisplay(newTestScreen(t, "UTF-8")) {
+		t.Error("expected a UTF-8 terminal to show the Unicode glyphs")
+	}
+}
+
+func TestSetResolution_ASCII(t *testing.T) {
+	r := NewRenderer(newTestScreen(t, "UTF-8"))
+	if res := r.SetResolution(BrailleResolution); res != BrailleResolution {
+		t.Errorf("expected braille on a UTF-8 terminal, got %s", res)
+	}
+
+	UseGlyphs(ASCIIGlyphs())
+	defer UseGlyphs(UnicodeGlyphs())
+	if res := r.SetResolution(BrailleResolution); res != CellResolution {
+		t.Errorf("expected whole cells with ASCII glyphs, got %s", res)
+	}
+}
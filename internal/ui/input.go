@@ -6,19 +6,28 @@ import (
 )
 
 // KeyToDirection converts a key event to a movement direction
-// For Pong, only up/down movement is allowed
+// Left/right move the top and bottom paddles in four-way mode, and the side
+// paddles toward the net when lateral movement is on
 func KeyToDirection(key tcell.Key, r rune) protocol.Direction {
 	switch key {
 	case tcell.KeyUp:
 		return protocol.DirUp
 	case tcell.KeyDown:
 		return protocol.DirDown
+	case tcell.KeyLeft:
+		return protocol.DirLeft
+	case tcell.KeyRight:
+		return protocol.DirRight
 	case tcell.KeyRune:
 		switch r {
 		case 'w', 'W':
 			return protocol.DirUp
 		case 's', 'S':
 			return protocol.DirDown
+		case 'a', 'A':
+			return protocol.DirLeft
+		case 'd', 'D':
+			return protocol.DirRight
 		}
 	}
 	return protocol.DirNone
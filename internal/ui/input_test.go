@@ -19,6 +19,10 @@ func TestKeyToDirection(t *testing.T) {
 		{tcell.KeyRune, 'W', protocol.DirUp},
 		{tcell.KeyRune, 's', protocol.DirDown},
 		{tcell.KeyRune, 'S', protocol.DirDown},
+		{tcell.KeyLeft, 0, protocol.DirLeft},
+		{tcell.KeyRight, 0, protocol.DirRight},
+		{tcell.KeyRune, 'a', protocol.DirLeft},
+		{tcell.KeyRune, 'D', protocol.DirRight},
 		{tcell.KeyRune, 'x', protocol.DirNone},
 	}
 
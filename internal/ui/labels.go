@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Arrows pointing at the player's own paddle
+const (
+	MarkerLeft  = '\u25C0' // ◀
+	MarkerRight = '\u25B6' // ▶
+	MarkerUp    = '\u25B2' // ▲
+	MarkerDown  = '\u25BC' // ▼
+)
+
+// NameLabelLength is how much of a name is shown next to a paddle
+const NameLabelLength = 8
+
+// SetPlayerID tells the renderer which paddle belongs to this player
+func (r *Renderer) SetPlayerID(id string) {
+	r.playerID = id
+}
+
+// ToggleLabels shows or hides the names next to the paddles and returns
+// whether they are shown now
+func (r *Renderer) ToggleLabels() bool {
+	r.hideLabels = !r.hideLabels
+	return !r.hideLabels
+}
+
+// paddleBox returns the screen cells a paddle covers: its first and last
+// column and row. Finer resolutions can put its ends in other cells.
+func paddleBox(paddle protocol.PaddleState, v courtView, res Resolution) (int, int, int, int) {
+	if res != CellResolution {
+		grid := newGrid(res, v)
+		return grid.cellBox(grid.paddleRect(paddle))
+	}
+	if paddle.Horizontal {
+		row := v.y(float64(paddle.Column))
+		half := float64(paddle.Height) / 2
+		left := v.x(paddle.Y - half)
+		right := max(left+1, v.x(paddle.Y+half))
+		return left, right - 1, row, row
+	}
+	x := v.x(float64(paddle.Column))
+	height := max(1, v.length(float64(paddle.Height)))
+	top := v.y(paddle.Y) - height/2
+	return x, x, top, top + height - 1
+}
+
+// renderIdentity marks the player's own paddle and, unless hidden, puts
+// each player's name next to their paddle
+func (r *Renderer) renderIdentity(paddles []protocol.PaddleState, sides []protocol.SideState, v courtView) {
+	for _, paddle := range paddles {
+		if sideOut(sides, paddle.Team) {
+			continue
+		}
+		own := paddle.ID == r.playerID
+		if own {
+			r.renderOwnMarker(paddle, v)
+		}
+		if !r.hideLabels && paddle.Name != "" {
+			r.renderNameLabel(paddle, own, v)
+		}
+	}
+}
+
+// renderOwnMarker draws an arrow on the court side of the player's paddle
+func (r *Renderer) renderOwnMarker(paddle protocol.PaddleState, v courtView) {
+	left, right, top, bottom := paddleBox(paddle, v, r.resolution)
+	style := tcell.StyleDefault.Foreground(GetPlayerColor(paddle.Color)).Bold(true)
+
+	x, y, marker := right+1, (top+bottom)/2, glyphs.MarkerLeft
+	switch {
+	case paddle.Horizontal && top < v.top+v.height/2:
+		x, y, marker = (left+right)/2, bottom+1, glyphs.MarkerUp
+	case paddle.Horizontal:
+		x, y, marker = (left+right)/2, top-1, glyphs.MarkerDown
+	case left >= v.left+v.width/2:
+		x, marker = left-1, glyphs.MarkerRight
+	}
+	if v.contains(x, y) {
+		r.screen.SetCell(x, y, style, marker)
+	}
+}
+
+// renderNameLabel writes a short name just off the paddle, toward the middle
+// of the court. The player's own name is drawn in reverse.
+func (r *Renderer) renderNameLabel(paddle protocol.PaddleState, own bool, v courtView) {
+	left, right, top, bottom := paddleBox(paddle, v, r.resolution)
+	label := truncate(paddle.Name, NameLabelLength)
+	style := tcell.StyleDefault.Foreground(GetPlayerColor(paddle.Color))
+	if own {
+		style = tcell.StyleDefault.Background(GetPlayerColor(paddle.Color)).Foreground(theme.Court).Bold(true)
+	}
+
+	var x, y int
+	if paddle.Horizontal {
+		// Beside the paddle's row, on the court side
+		x, y = (left+right-len(label)+1)/2, bottom+1
+		if top >= v.top+v.height/2 {
+			y = top - 1
+		}
+	} else {
+		// Above the paddle, or below it at the top of the court
+		x, y = left, top-1
+		if y < v.top {
+			y = bottom + 1
+		}
+		if left >= v.left+v.width/2 {
+			x = right - len(label) + 1
+		}
+	}
+
+	x = max(v.left, min(x, v.right()-len(label)+1))
+	if y < v.top || y > v.bottom() {
+		return
+	}
+	r.screen.DrawText(x, y, label, style)
+}
+
+// teamRosters lists the names on each team, in paddle order
+func teamRosters(paddles []protocol.PaddleState) map[protocol.Team]string {
+	names := make(map[protocol.Team][]string)
+	for _, paddle := range paddles {
+		if paddle.Name != "" {
+			names[paddle.Team] = append(names[paddle.Team], paddle.Name)
+		}
+	}
+	rosters := make(map[protocol.Team]string, len(names))
+	for team, list := range names {
+		rosters[team] = strings.Join(list, ", ")
+	}
+	return rosters
+}
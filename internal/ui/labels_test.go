@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+)
+
+func TestTeamRosters(t *testing.T) {
+	rosters := teamRosters([]protocol.PaddleState{
+		{Name: "Ann", Team: protocol.TeamLeft},
+		{Name: "Cy", Team: protocol.TeamRight},
+		{Name: "Bob", Team: protocol.TeamLeft},
+		{Team: protocol.TeamRight},
+	})
+	if rosters[protocol.TeamLeft] != "Ann, Bob" || rosters[protocol.TeamRight] != "Cy" {
+		t.Errorf("unexpected rosters %v", rosters)
+	}
+}
+
+func TestPaddleBox(t *testing.T) {
+	v := newCourtView(80, 24, 80, 26)
+
+	left, right, top, bottom := paddleBox(protocol.PaddleState{Column: 2, Y: 12, Height: 4}, v, CellResolution)
+	if left != 2 || right != 2 || top != 11 || bottom != 14 {
+		t.Errorf("expected column 2 rows 11-14, got %d-%d rows %d-%d", left, right, top, bottom)
+	}
+
+	left, right, top, bottom = paddleBox(protocol.PaddleState{Column: 0, Y: 40, Height: 6, Horizontal: true}, v, CellResolution)
+	if left != 37 || right != 42 || top != 1 || bottom != 1 {
+		t.Errorf("expected columns 37-42 on row 1, got %d-%d rows %d-%d", left, right, top, bottom)
+	}
+
+	// A quarter cell off in braille, the paddle reaches into one more row
+	left, right, top, bottom = paddleBox(protocol.PaddleState{Column: 2, Y: 12.25, Height: 4}, v, BrailleResolution)
+	if left != 2 || right != 2 || top != 11 || bottom != 15 {
+		t.Errorf("expected column 2 rows 11-15, got %d-%d rows %d-%d", left, right, top, bottom)
+	}
+}
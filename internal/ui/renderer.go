@@ -3,28 +3,109 @@ package ui
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/diegok/pixpong/internal/history"
 	"github.com/diegok/pixpong/internal/protocol"
 )
 
 const (
 	BallChar   = '\u2B24' // ⬤
 	PaddleChar = '\u2588' // █
+	ShieldChar = '\u2503' // ┃
+	BlockChar  = '\u2593' // ▓
+	BumperChar = '\u25CF' // ●
+	GoalWall   = '\u2551' // ║
+	SideWall   = '\u2550' // ═
 )
 
+// Spin at which a ball is drawn in a warmer color
+const (
+	VisibleSpin = 0.003
+	StrongSpin  = 0.01
+)
+
+// lobbyTeamColumn is where team tags start in the lobby and rematch lists
+const lobbyTeamColumn = 32
+
+// PowerUpChars maps each power-up to the glyph drawn on the court
+var PowerUpChars = map[protocol.PowerUpKind]rune{
+	protocol.PowerUpGrow:      '+',
+	protocol.PowerUpShrink:    '-',
+	protocol.PowerUpSpeed:     '\u00BB', // »
+	protocol.PowerUpReverse:   '\u21C5', // ⇅
+	protocol.PowerUpExtraBall: '\u25CE', // ◎
+	protocol.PowerUpShield:    '\u25A3', // ▣
+}
+
+// PowerUpColors maps each power-up to its color in the classic theme
+var PowerUpColors = map[protocol.PowerUpKind]tcell.Color{
+	protocol.PowerUpGrow:      tcell.ColorGreen,
+	protocol.PowerUpShrink:    tcell.ColorRed,
+	protocol.PowerUpSpeed:     tcell.ColorYellow,
+	protocol.PowerUpReverse:   tcell.ColorFuchsia,
+	protocol.PowerUpExtraBall: tcell.ColorTeal,
+	protocol.PowerUpShield:    tcell.ColorBlue,
+}
+
+// PowerUpNames maps each power-up to the label shown in the status bar
+var PowerUpNames = map[protocol.PowerUpKind]string{
+	protocol.PowerUpGrow:      "GROW",
+	protocol.PowerUpShrink:    "SHRINK",
+	protocol.PowerUpSpeed:     "SPEED",
+	protocol.PowerUpReverse:   "REVERSE",
+	protocol.PowerUpExtraBall: "EXTRA BALL",
+	protocol.PowerUpShield:    "SHIELD",
+}
+
 // Renderer handles rendering all game screens
 type Renderer struct {
-	screen *Screen
+	screen     *Screen
+	resolution Resolution
+	effects    *Effects // Nil when effects are off
+	playerID   string   // Paddle of the player at this screen
+	hideLabels bool     // Names next to the paddles toggled off
+	truecolor  bool     // The terminal can show the theme's gradients
 }
 
 // NewRenderer creates a new renderer with the given screen
 func NewRenderer(screen *Screen) *Renderer {
-	return &Renderer{screen: screen}
+	return &Renderer{
+		screen:    screen,
+		effects:   NewEffects(),
+		truecolor: screen.Colors() >= 1<<24,
+	}
+}
+
+// SetEffects turns ball trails and hit effects on or off
+func (r *Renderer) SetEffects(on bool) {
+	r.effects = nil
+	if on {
+		r.effects = NewEffects()
+	}
+}
+
+// UpdateEffects feeds each new game state to the effects, if they are on
+func (r *Renderer) UpdateEffects(state protocol.GameState) {
+	if r.effects != nil {
+		r.effects.Update(state)
+	}
+}
+
+// SetResolution sets how finely balls and paddles are drawn. Terminals that
+// can't show the glyphs get the next coarser resolution, which is returned.
+func (r *Renderer) SetResolution(res Resolution) Resolution {
+	for res > CellResolution && (glyphs.ASCII || !r.screen.CanDisplay(res.glyph())) {
+		res--
+	}
+	r.resolution = res
+	return res
 }
 
 // RenderLobby displays the lobby screen
-func (r *Renderer) RenderLobby(state protocol.LobbyState) {
+// The cursor marks the player selected by the host, or -1 for none.
+func (r *Renderer) RenderLobby(state protocol.LobbyState, cursor int) {
 	r.screen.Clear()
 	screenW, screenH := r.screen.Size()
 
@@ -40,10 +121,33 @@ func (r *Renderer) RenderLobby(state protocol.LobbyState) {
 	r.screen.DrawText(4, playerListY, playersLabel, tcell.StyleDefault.Foreground(tcell.ColorGray))
 
 	for i, player := range state.Players {
+		y := playerListY + 1 + i
+		if i == cursor {
+			r.screen.DrawText(4, y, ">", tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true))
+		}
 		playerStyle := GetPlayerStyle(player.Color)
 		playerText := fmt.Sprintf("  %s", player.Name)
-		r.screen.DrawText(4, playerListY+1+i, playerText, playerStyle)
+		r.screen.DrawText(4, y, playerText, playerStyle)
+		if player.IsBot {
+			r.screen.DrawText(4+len(playerText)+1, y, "[BOT]", tcell.StyleDefault.Foreground(tcell.ColorGray))
+		} else if player.Rating > 0 {
+			r.screen.DrawText(4+len(playerText)+1, y, fmt.Sprintf("(%d)", player.Rating), tcell.StyleDefault.Foreground(tcell.ColorGray))
+		}
+		teamText, teamStyle := preferenceLabel(player.Preference)
+		if player.QueuePos > 0 {
+			teamText, teamStyle = queueLabel(player.QueuePos)
+		}
+		if state.Tournament != "" && state.TeamSize > 1 {
+			teamText, teamStyle = entrantLabel(player.Entrant)
+		}
+		r.screen.DrawText(lobbyTeamColumn, y, teamText, teamStyle)
 	}
+	if state.TeamsLocked {
+		r.screen.DrawText(4, playerListY+len(state.Players)+1, "Teams locked by host", tcell.StyleDefault.Foreground(tcell.ColorYellow))
+	}
+
+	// Match history of the players here, beside the player list
+	r.renderStandings(state.Standings, state.Skipped, playerListY, screenW)
 
 	// Server addresses (for host only)
 	if state.IsHost && len(state.ServerAddrs) > 0 {
@@ -59,13 +163,80 @@ func (r *Renderer) RenderLobby(state protocol.LobbyState) {
 	ptY := screenH - 6
 	ptText := fmt.Sprintf("Points to win: %d", state.PointsToWin)
 	r.screen.DrawText(4, ptY, ptText, tcell.StyleDefault.Foreground(tcell.ColorTeal))
+	var extras []string
+	if state.PowerUps {
+		extras = append(extras, "Power-ups: on")
+	}
+	if state.Balls > 1 {
+		extras = append(extras, fmt.Sprintf("Balls: %d", state.Balls))
+	}
+	if state.BallEvery > 0 {
+		extras = append(extras, fmt.Sprintf("+1 ball every %d hits", state.BallEvery))
+	}
+	if state.WinByTwo {
+		extras = append(extras, "Win by two")
+	}
+	if state.Sets > 1 {
+		extras = append(extras, fmt.Sprintf("Best of %d sets", state.Sets))
+	}
+	if state.TimeLimit > 0 {
+		extras = append(extras, fmt.Sprintf("Time limit: %s", formatClock(state.TimeLimit)))
+	}
+	if state.MapName != "" {
+		extras = append(extras, fmt.Sprintf("Map: %s", state.MapName))
+	}
+	if state.FourWay {
+		extras = append(extras, "Four-way")
+	}
+	if state.CourtWidth > 0 {
+		extras = append(extras, fmt.Sprintf("Court: %dx%d", state.CourtWidth, state.CourtHeight))
+	}
+	if state.ServeRule != "" && state.ServeRule != "standard" {
+		extras = append(extras, fmt.Sprintf("Serve: %s", state.ServeRule))
+	}
+	if state.ServeTime > 0 {
+		extras = append(extras, fmt.Sprintf("Serve clock: %ds", state.ServeTime))
+	}
+	if state.Pauses > 0 {
+		extras = append(extras, fmt.Sprintf("Pauses: %d per team", state.Pauses))
+	}
+	if state.King > 0 {
+		extras = append(extras, fmt.Sprintf("Winner stays on: %d per side", state.King))
+	}
+	if state.Tournament != "" {
+		tournamentText := fmt.Sprintf("Tournament: %s elimination", state.Tournament)
+		if state.TeamSize > 1 {
+			tournamentText += fmt.Sprintf(", teams of %d in join order", state.TeamSize)
+		}
+		extras = append(extras, tournamentText)
+	}
+	switch state.Lateral {
+	case "half":
+		extras = append(extras, "Paddles move in the team half")
+	case "lane":
+		extras = append(extras, "Paddles move in their lane")
+	}
+	if len(extras) > 0 {
+		r.screen.DrawText(4, ptY+1, strings.Join(extras, " | "), tcell.StyleDefault.Foreground(tcell.ColorTeal))
+	}
+	if state.Rules.Name != "" {
+		r.screen.DrawText(4, ptY-1, rulesText(state.Rules), tcell.StyleDefault.Foreground(tcell.ColorTeal))
+	}
+	if state.BotDifficulty != "" {
+		botText := fmt.Sprintf("Bot difficulty: %s", state.BotDifficulty)
+		r.screen.DrawText(4+len(ptText)+4, ptY, botText, tcell.StyleDefault.Foreground(tcell.ColorTeal))
+	}
 
 	// Instructions
 	instructY := screenH - 4
 	var instructions string
 	if state.IsHost {
-		if state.CanStart {
+		if state.CanStart && state.Tournament != "" {
+			instructions = "Press ENTER to start the tournament"
+		} else if state.CanStart {
 			instructions = "Press ENTER to start game"
+		} else if state.NotReady != "" {
+			instructions = "Can't start: " + state.NotReady
 		} else {
 			instructions = "Waiting for more players..."
 		}
@@ -75,6 +246,31 @@ func (r *Renderer) RenderLobby(state protocol.LobbyState) {
 	instructStyle := tcell.StyleDefault.Foreground(tcell.ColorGreen)
 	r.screen.DrawText(4, instructY, instructions, instructStyle)
 
+	// Team selection keys
+	var teamHint string
+	if state.King > 0 {
+		teamHint = "The line decides the sides: losers go to the back after each match"
+		if state.IsHost {
+			teamHint += " | P: rules"
+		}
+	} else if state.Tournament != "" && state.TeamSize > 1 {
+		teamHint = "The host sets up the teams, everyone else fills the open places"
+		if state.IsHost {
+			teamHint = "Up/Down: select | Left/Right: team number | R: any team | P: rules"
+		}
+	} else if state.IsHost {
+		teamHint = "Up/Down: select | Left/Right: move | R: random | B: balance | E: balance by rating | L: lock | P: rules"
+		if state.FourWay {
+			teamHint = "Up/Down: select | Left/Right/W/S: move | R: random | B: balance | E: balance by rating | L: lock | P: rules"
+		}
+	} else if !state.TeamsLocked {
+		teamHint = "Left/Right: pick a team | R: random"
+		if state.FourWay {
+			teamHint = "Left/Right/W/S: pick a team | R: random"
+		}
+	}
+	r.screen.DrawText(4, instructY+1, teamHint, tcell.StyleDefault.Foreground(tcell.ColorGray))
+
 	// Quit hint
 	quitText := "Press 'q' to quit"
 	r.screen.DrawText(4, screenH-2, quitText, tcell.StyleDefault.Foreground(tcell.ColorGray))
@@ -82,136 +278,448 @@ func (r *Renderer) RenderLobby(state protocol.LobbyState) {
 	r.screen.Show()
 }
 
+// renderStandings draws the lobby leaderboard panel, if there is room for it
+func (r *Renderer) renderStandings(standings []protocol.Standing, skipped, y, screenW int) {
+	const panelW = 36
+	x := screenW - panelW - 2
+	if (len(standings) == 0 && skipped == 0) || x < lobbyTeamColumn+12 {
+		return
+	}
+
+	r.screen.DrawText(x, y, "Leaderboard:", tcell.StyleDefault.Foreground(tcell.ColorGray))
+	header := fmt.Sprintf("%-12s %4s %4s %5s %6s", "Player", "W", "L", "Win%", "Streak")
+	r.screen.DrawText(x, y+1, header, tcell.StyleDefault.Foreground(tcell.ColorGray).Bold(true))
+	for i, st := range standings {
+		winRate := 0
+		if played := st.Wins + st.Losses; played > 0 {
+			winRate = st.Wins * 100 / played
+		}
+		row := fmt.Sprintf("%-12s %4d %4d %4d%% %6s", truncate(st.Name, 12), st.Wins, st.Losses, winRate, history.StreakText(st.Streak))
+		style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+		if i == 0 {
+			style = style.Foreground(theme.Leader)
+		}
+		r.screen.DrawText(x, y+2+i, row, style)
+	}
+	if skipped > 0 {
+		note := fmt.Sprintf("Damaged history lines skipped: %d", skipped)
+		r.screen.DrawText(x, y+2+len(standings), note, tcell.StyleDefault.Foreground(tcell.ColorRed))
+	}
+}
+
+// rulesText describes the rules of the next match in one line
+func rulesText(rules protocol.RulesInfo) string {
+	return fmt.Sprintf("Rules: %s | Ball speed %.2f, +%.0f%% per hit, up to %.2f | Paddle %d | Angle %d%s",
+		rules.Name, rules.BallSpeed, (rules.SpeedIncrement-1)*100, rules.SpeedCap, rules.PaddleHeight, rules.MaxBounceAngle, glyphs.Degree)
+}
+
 // RenderGame displays the game screen
 func (r *Renderer) RenderGame(state protocol.GameState) {
 	r.screen.Clear()
+	r.drawGame(state)
+	r.screen.Show()
+}
+
+// drawGame draws the court, paddles, balls and status bar
+func (r *Renderer) drawGame(state protocol.GameState) {
 	screenW, screenH := r.screen.Size()
 
-	// Calculate scale factors to map court coordinates to screen coordinates
-	// Court coordinates come from the smallest terminal, screen may be larger
-	scaleX := float64(screenW) / float64(state.CourtWidth)
-	scaleY := float64(screenH-2) / float64(state.CourtHeight) // -2 for status bars
+	// Scale the court the same way on both axes, letterboxed to fit
+	v := newCourtView(state.CourtWidth, state.CourtHeight, screenW, screenH)
+	r.renderCourtArea(v, screenW, screenH)
 
-	// Draw court background (black)
-	courtStyle := tcell.StyleDefault.Background(tcell.ColorBlack)
-	r.screen.FillRect(0, 1, screenW, screenH-2, courtStyle, ' ')
+	// Draw scoreboard at top center
+	r.renderScoreboard(state.LeftScore, state.RightScore, state.Match, state.Sides, teamRosters(state.Paddles), screenW)
 
-	// Draw center dashed line
-	centerX := screenW / 2
-	lineStyle := tcell.StyleDefault.Foreground(tcell.ColorDarkGray)
-	for y := 1; y < screenH-1; y += 2 {
-		r.screen.SetCell(centerX, y, lineStyle, '|')
+	// Draw map obstacles and goal walls
+	r.renderCourt(state.Court, state.CourtHeight, v)
+	r.renderClosedSides(state.Sides, v)
+
+	// Trails and ripples go under the paddles and balls
+	if r.effects != nil {
+		r.renderTrails(v)
+		r.renderBursts(v, rippleBurst)
 	}
 
-	// Draw scoreboard at top center
-	r.renderScoreboard(state, screenW)
+	// Balls and paddles can be drawn finer than a cell
+	var dots *canvas
+	if r.resolution != CellResolution {
+		dots = newCanvas(r.resolution, v)
+	}
 
 	// Draw all paddles (scaled to screen size)
 	for _, paddle := range state.Paddles {
+		if sideOut(state.Sides, paddle.Team) {
+			continue
+		}
 		paddleStyle := GetPlayerStyle(paddle.Color)
-		// Scale paddle position and height using rounding for smoother movement
-		scaledX := int(math.Round(float64(paddle.Column) * scaleX))
-		scaledY := int(math.Round(paddle.Y*scaleY)) + 1 // +1 for top status bar
-		scaledHeight := int(math.Round(float64(paddle.Height) * scaleY))
-		if scaledHeight < 1 {
-			scaledHeight = 1
+		paddleColor := GetPlayerColor(paddle.Color)
+		// A paddle lights up for a moment when it hits the ball
+		if r.effects != nil && r.effects.flashing(paddle.ID) {
+			paddleStyle = paddleStyle.Foreground(theme.Flash).Bold(true)
+			paddleColor = theme.Flash
+		}
+		if dots != nil {
+			dots.paddle(paddle, paddleColor)
+			continue
+		}
+		if paddle.Horizontal {
+			r.renderRowPaddle(paddle, paddleStyle, v)
+			continue
 		}
+		// Scale paddle position and height using rounding for smoother movement
+		scaledX := v.x(float64(paddle.Column))
+		scaledY := v.y(paddle.Y)
+		scaledHeight := max(1, v.length(float64(paddle.Height)))
 
 		paddleTop := scaledY - scaledHeight/2
 		for dy := 0; dy < scaledHeight; dy++ {
 			py := paddleTop + dy
-			if py >= 1 && py < screenH-1 {
-				r.screen.SetCell(scaledX, py, paddleStyle, PaddleChar)
+			if v.contains(scaledX, py) {
+				r.screen.SetCell(scaledX, py, paddleStyle, glyphs.Paddle)
 			}
 		}
 	}
 
-	// Draw ball (scaled to screen size, using rounding for smoother diagonal movement)
-	ballX := int(math.Round(state.Ball.X * scaleX))
-	ballY := int(math.Round(state.Ball.Y*scaleY)) + 1 // +1 for top status bar
-	if ballX >= 0 && ballX < screenW && ballY >= 1 && ballY < screenH-1 {
-		ballStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
-		r.screen.SetCell(ballX, ballY, ballStyle, BallChar)
+	// Own paddle marker and name labels
+	r.renderIdentity(state.Paddles, state.Sides, v)
+
+	// Draw power-up items and shields
+	r.renderPowerUps(state, v)
+
+	// Draw balls (scaled to screen size, using rounding for smoother diagonal movement)
+	for _, ball := range append([]protocol.BallState{state.Ball}, state.ExtraBalls...) {
+		if dots != nil {
+			color, _, _ := ballStyle(ball).Decompose()
+			dots.ball(ball, color)
+			continue
+		}
+		ballX, ballY := v.x(ball.X), v.y(ball.Y)
+		if v.contains(ballX, ballY) {
+			r.screen.SetCell(ballX, ballY, ballStyle(ball), glyphs.Ball)
+		}
+	}
+	if dots != nil {
+		dots.draw(r.screen)
+	}
+
+	// Goal explosions go on top of everything
+	if r.effects != nil {
+		r.renderBursts(v, explosionBurst)
 	}
 
 	// Status bar at bottom
 	statusY := screenH - 1
-	statusStyle := tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorWhite)
+	statusStyle := tcell.StyleDefault.Background(theme.Bar).Foreground(theme.BarText)
 	for x := 0; x < screenW; x++ {
 		r.screen.SetCell(x, statusY, statusStyle, ' ')
 	}
-	statusText := fmt.Sprintf(" Tick: %d | First to %d wins", state.Tick, state.PointsToWin)
+	statusText := fmt.Sprintf(" Tick: %d | %s", state.Tick, describeFormat(state.PointsToWin, state.Match))
 	r.screen.DrawText(0, statusY, statusText, statusStyle)
 
-	r.screen.Show()
+	// Active power-up effects, right-aligned in team colors
+	effectX := screenW
+	for i := len(state.Effects) - 1; i >= 0; i-- {
+		effect := state.Effects[i]
+		effectText := fmt.Sprintf(" %s %ds ", PowerUpNames[effect.Kind], effect.SecondsLeft)
+		effectX -= len(effectText)
+		if effectX <= len(statusText) {
+			break
+		}
+		effectStyle := statusStyle.Foreground(teamColor(effect.Team)).Bold(true)
+		r.screen.DrawText(effectX, statusY, effectText, effectStyle)
+	}
+}
+
+// ballStyle colors a ball by how much it spins, so players can see it curve
+func ballStyle(ball protocol.BallState) tcell.Style {
+	switch spin := math.Abs(ball.Spin); {
+	case spin >= StrongSpin:
+		return tcell.StyleDefault.Foreground(theme.BallStrongSpin)
+	case spin >= VisibleSpin:
+		return tcell.StyleDefault.Foreground(theme.BallSpin)
+	}
+	return tcell.StyleDefault.Foreground(theme.Ball)
+}
+
+// renderRowPaddle draws a horizontal paddle guarding the top or bottom edge
+func (r *Renderer) renderRowPaddle(paddle protocol.PaddleState, style tcell.Style, v courtView) {
+	row := v.y(float64(paddle.Column))
+	half := float64(paddle.Height) / 2
+	left := v.x(paddle.Y - half)
+	right := max(left+1, v.x(paddle.Y+half))
+	for x := left; x < right; x++ {
+		if v.contains(x, row) {
+			r.screen.SetCell(x, row, style, glyphs.Paddle)
+		}
+	}
+}
+
+// renderClosedSides draws a wall on the edges of teams knocked out of a four-way match
+func (r *Renderer) renderClosedSides(sides []protocol.SideState, v courtView) {
+	wallStyle := tcell.StyleDefault.Foreground(theme.Walls)
+	for _, side := range sides {
+		if side.Out {
+			r.edgeLine(v, side.Team, wallStyle, glyphs.GoalWall, glyphs.SideWall)
+		}
+	}
+}
+
+// sideOut returns true if the team has been knocked out of a four-way match
+func sideOut(sides []protocol.SideState, team protocol.Team) bool {
+	for _, side := range sides {
+		if side.Team == team {
+			return side.Out
+		}
+	}
+	return false
+}
+
+// renderPowerUps draws the items on the court and any active goal shields
+func (r *Renderer) renderPowerUps(state protocol.GameState, v courtView) {
+	for _, item := range state.PowerUps {
+		x, y := v.x(item.X), v.y(item.Y)
+		if v.contains(x, y) {
+			style := tcell.StyleDefault.Foreground(theme.PowerUps[item.Kind]).Bold(true)
+			r.screen.SetCell(x, y, style, glyphs.PowerUps[item.Kind])
+		}
+	}
+
+	for _, effect := range state.Effects {
+		if effect.Kind != protocol.PowerUpShield {
+			continue
+		}
+		style := tcell.StyleDefault.Foreground(teamColor(effect.Team))
+		r.edgeLine(v, effect.Team, style, glyphs.Shield, glyphs.SideWall)
+	}
+}
+
+// renderCourt draws the map blocks, bumpers and the closed parts of the goal lines
+func (r *Renderer) renderCourt(court protocol.CourtLayout, courtHeight int, v courtView) {
+	blockStyle := tcell.StyleDefault.Foreground(theme.Walls)
+	for _, block := range court.Blocks {
+		left := v.x(block.X)
+		right := max(left+1, v.x(block.X+block.W))
+		top := v.y(block.Y)
+		bottom := max(top+1, v.y(block.Y+block.H))
+		for y := top; y < bottom; y++ {
+			for x := left; x < right; x++ {
+				if v.contains(x, y) {
+					r.screen.SetCell(x, y, blockStyle, glyphs.Block)
+				}
+			}
+		}
+	}
+
+	// Bumpers are circles in court cells, so they are drawn cell by cell
+	bumperStyle := tcell.StyleDefault.Foreground(theme.Bumpers)
+	for _, bumper := range court.Bumpers {
+		left := int(math.Floor((bumper.X - bumper.R) * v.scale))
+		right := int(math.Ceil((bumper.X + bumper.R) * v.scale))
+		top := int(math.Floor((bumper.Y - bumper.R) * v.scale))
+		bottom := int(math.Ceil((bumper.Y + bumper.R) * v.scale))
+		for sy := top; sy <= bottom; sy++ {
+			for sx := left; sx <= right; sx++ {
+				cx := (float64(sx) + 0.5) / v.scale
+				cy := (float64(sy) + 0.5) / v.scale
+				x, y := v.left+sx, v.top+sy
+				if math.Hypot(cx-bumper.X, cy-bumper.Y) <= bumper.R && v.contains(x, y) {
+					r.screen.SetCell(x, y, bumperStyle, glyphs.Bumper)
+				}
+			}
+		}
+		// Always show at least the center of a small bumper
+		centerX, centerY := v.x(bumper.X), v.y(bumper.Y)
+		if v.contains(centerX, centerY) {
+			r.screen.SetCell(centerX, centerY, bumperStyle, glyphs.Bumper)
+		}
+	}
+
+	// Walls on both edges outside the goal mouth
+	if court.GoalTop <= 0 && court.GoalBottom >= float64(courtHeight) {
+		return
+	}
+	wallStyle := tcell.StyleDefault.Foreground(theme.Walls)
+	goalTop, goalBottom := v.y(court.GoalTop), v.y(court.GoalBottom)
+	for y := v.top; y <= v.bottom(); y++ {
+		if y >= goalTop && y <= goalBottom {
+			continue
+		}
+		r.screen.SetCell(v.left, y, wallStyle, glyphs.GoalWall)
+		r.screen.SetCell(v.right(), y, wallStyle, glyphs.GoalWall)
+	}
 }
 
-// renderScoreboard draws a stadium-style scoreboard at top center
-func (r *Renderer) renderScoreboard(state protocol.GameState, screenW int) {
-	// Scoreboard format: [ LEFT  3 - 2  RIGHT ]
-	leftScoreStr := fmt.Sprintf("%d", state.LeftScore)
-	rightScoreStr := fmt.Sprintf("%d", state.RightScore)
-	separator := " - "
-	leftLabel := "LEFT"
-	rightLabel := "RIGHT"
+// teamColor returns the color used for a team's labels
+func teamColor(team protocol.Team) tcell.Color {
+	if color, ok := theme.Teams[team]; ok {
+		return color
+	}
+	return tcell.ColorWhite
+}
 
-	scoreboardText := fmt.Sprintf("[ %s %s%s%s %s ]", leftLabel, leftScoreStr, separator, rightScoreStr, rightLabel)
-	scoreboardX := (screenW - len(scoreboardText)) / 2
+// teamName returns a team's name in capitals
+func teamName(team protocol.Team) string {
+	switch team {
+	case protocol.TeamLeft:
+		return "LEFT"
+	case protocol.TeamTop:
+		return "TOP"
+	case protocol.TeamBottom:
+		return "BOTTOM"
+	}
+	return "RIGHT"
+}
 
-	// Draw scoreboard background
-	scoreboardStyle := tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorWhite).Bold(true)
+// renderScoreboard draws a stadium-style scoreboard at top center, with the
+// team rosters next to it when they fit
+func (r *Renderer) renderScoreboard(leftScore, rightScore int, match protocol.MatchInfo, sides []protocol.SideState, rosters map[protocol.Team]string, screenW int) {
+	// Scoreboard format: [ LEFT  3 - 2  RIGHT ], with set counts in
+	// best-of-N matches: [ LEFT (1) 3 - 2 (0) RIGHT ]
+	scoreboardStyle := tcell.StyleDefault.Background(theme.Bar).Foreground(theme.BarText).Bold(true)
+	leftStyle := tcell.StyleDefault.Background(theme.Bar).Foreground(teamColor(protocol.TeamLeft)).Bold(true)
+	rightStyle := tcell.StyleDefault.Background(theme.Bar).Foreground(teamColor(protocol.TeamRight)).Bold(true)
+	setStyle := tcell.StyleDefault.Background(theme.Bar).Foreground(tcell.ColorYellow)
+
+	type segment struct {
+		text  string
+		style tcell.Style
+	}
 
-	// Draw opening bracket and left label
-	r.screen.DrawText(scoreboardX, 0, "[ ", scoreboardStyle)
+	// Four-way format: [ LEFT 3 | RIGHT 2 | TOP 0 | BOTTOM OUT ], with
+	// each roster after the team name if the screen is wide enough
+	if len(sides) > 0 {
+		var segments []segment
+		width := 0
+		for _, withRosters := range []bool{true, false} {
+			segments = []segment{{"[ ", scoreboardStyle}}
+			for i, side := range sides {
+				if i > 0 {
+					segments = append(segments, segment{" | ", scoreboardStyle})
+				}
+				score := fmt.Sprintf(" %d", side.Score)
+				if side.Out {
+					score = " OUT"
+				}
+				name := teamName(side.Team)
+				if withRosters && rosters[side.Team] != "" {
+					name += " (" + rosters[side.Team] + ")"
+				}
+				nameStyle := tcell.StyleDefault.Background(theme.Bar).Foreground(teamColor(side.Team)).Bold(true)
+				segments = append(segments, segment{name, nameStyle}, segment{score, scoreboardStyle})
+			}
+			segments = append(segments, segment{" ]", scoreboardStyle})
 
-	// Left team color
-	leftStyle := tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorRed).Bold(true)
-	r.screen.DrawText(scoreboardX+2, 0, leftLabel, leftStyle)
-	r.screen.DrawText(scoreboardX+2+len(leftLabel), 0, " ", scoreboardStyle)
+			width = 0
+			for _, seg := range segments {
+				width += len(seg.text)
+			}
+			if width <= screenW {
+				break
+			}
+		}
+		x := (screenW - width) / 2
+		for _, seg := range segments {
+			r.screen.DrawText(x, 0, seg.text, seg.style)
+			x += len(seg.text)
+		}
+		return
+	}
 
-	// Left score
-	leftScoreStyle := tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorWhite).Bold(true)
-	r.screen.DrawText(scoreboardX+3+len(leftLabel), 0, leftScoreStr, leftScoreStyle)
+	segments := []segment{{"[ ", scoreboardStyle}, {"LEFT", leftStyle}, {" ", scoreboardStyle}}
+	if match.Sets > 1 {
+		segments = append(segments, segment{fmt.Sprintf("(%d) ", match.LeftSets), setStyle})
+	}
+	segments = append(segments,
+		segment{fmt.Sprintf("%d", leftScore), scoreboardStyle},
+		segment{" - ", scoreboardStyle},
+		segment{fmt.Sprintf("%d", rightScore), scoreboardStyle},
+	)
+	if match.Sets > 1 {
+		segments = append(segments, segment{fmt.Sprintf(" (%d)", match.RightSets), setStyle})
+	}
+	segments = append(segments, segment{" ", scoreboardStyle}, segment{"RIGHT", rightStyle}, segment{" ]", scoreboardStyle})
 
-	// Separator
-	r.screen.DrawText(scoreboardX+3+len(leftLabel)+len(leftScoreStr), 0, separator, scoreboardStyle)
+	width := 0
+	for _, seg := range segments {
+		width += len(seg.text)
+	}
 
-	// Right score
-	rightScoreX := scoreboardX + 3 + len(leftLabel) + len(leftScoreStr) + len(separator)
-	rightScoreStyle := tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorWhite).Bold(true)
-	r.screen.DrawText(rightScoreX, 0, rightScoreStr, rightScoreStyle)
-	r.screen.DrawText(rightScoreX+len(rightScoreStr), 0, " ", scoreboardStyle)
+	x := (screenW - width) / 2
+	boardLeft := x
+	for _, seg := range segments {
+		r.screen.DrawText(x, 0, seg.text, seg.style)
+		x += len(seg.text)
+	}
+
+	// Match clock next to the scoreboard
+	if match.TimeLimit {
+		clockText := " " + formatClock(match.TimeLeft) + " "
+		clockStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+		if match.Overtime {
+			clockText = " OVERTIME "
+			clockStyle = tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+		}
+		r.screen.DrawText(x+1, 0, clockText, clockStyle)
+		x += len(clockText) + 1
+	}
 
-	// Right team color
-	rightStyle := tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorBlue).Bold(true)
-	rightLabelX := rightScoreX + len(rightScoreStr) + 1
-	r.screen.DrawText(rightLabelX, 0, rightLabel, rightStyle)
+	// Team rosters either side, cut short on narrow screens
+	if left := truncate(rosters[protocol.TeamLeft], max(0, boardLeft-2)); left != "" {
+		r.screen.DrawText(boardLeft-1-len(left), 0, left, tcell.StyleDefault.Foreground(teamColor(protocol.TeamLeft)))
+	}
+	if right := truncate(rosters[protocol.TeamRight], max(0, screenW-x-2)); right != "" {
+		r.screen.DrawText(x+1, 0, right, tcell.StyleDefault.Foreground(teamColor(protocol.TeamRight)))
+	}
+}
 
-	// Closing bracket
-	r.screen.DrawText(rightLabelX+len(rightLabel), 0, " ]", scoreboardStyle)
+// formatClock formats seconds as m:ss
+func formatClock(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// describeFormat summarizes the match rules in one line
+func describeFormat(pointsToWin int, match protocol.MatchInfo) string {
+	text := fmt.Sprintf("First to %d wins", pointsToWin)
+	if match.WinByTwo {
+		text += " by 2"
+	}
+	if match.Sets > 1 {
+		text += fmt.Sprintf(" | Best of %d sets", match.Sets)
+	}
+	return text
 }
 
 // RenderPause displays the pause/serve screen
 func (r *Renderer) RenderPause(state protocol.PauseState) {
 	r.screen.Clear()
+	if state.PausedBy != "" && state.Game != nil {
+		r.drawGame(*state.Game)
+		r.renderPausedBox(state)
+		r.screen.Show()
+		return
+	}
+	if state.WaitingForServe && state.Game != nil {
+		r.drawGame(*state.Game)
+		r.renderServeBox(state)
+		r.screen.Show()
+		return
+	}
 	screenW, screenH := r.screen.Size()
 
-	// Draw court background (black)
-	courtStyle := tcell.StyleDefault.Background(tcell.ColorBlack)
+	// Draw court background
+	courtStyle := tcell.StyleDefault.Background(theme.Court)
 	r.screen.FillRect(0, 1, screenW, screenH-2, courtStyle, ' ')
 
 	// Draw center dashed line
 	centerX := screenW / 2
-	lineStyle := tcell.StyleDefault.Foreground(tcell.ColorDarkGray)
+	lineStyle := tcell.StyleDefault.Foreground(theme.CenterLine)
 	for y := 1; y < screenH-1; y += 2 {
 		r.screen.SetCell(centerX, y, lineStyle, '|')
 	}
 
 	// Draw scoreboard
-	scoreText := fmt.Sprintf("[ LEFT %d - %d RIGHT ]", state.LeftScore, state.RightScore)
-	scoreX := (screenW - len(scoreText)) / 2
-	scoreStyle := tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorWhite).Bold(true)
-	r.screen.DrawText(scoreX, 0, scoreText, scoreStyle)
+	r.renderScoreboard(state.LeftScore, state.RightScore, state.Match, state.Sides, nil, screenW)
 
 	// Center message box
 	boxW := 30
@@ -222,7 +730,7 @@ func (r *Renderer) RenderPause(state protocol.PauseState) {
 	r.screen.DrawBox(boxX, boxY, boxW, boxH, boxStyle)
 
 	// Fill box background
-	fillStyle := tcell.StyleDefault.Background(tcell.ColorDarkGray)
+	fillStyle := tcell.StyleDefault.Background(theme.Bar)
 	for y := boxY + 1; y < boxY+boxH-1; y++ {
 		for x := boxX + 1; x < boxX+boxW-1; x++ {
 			r.screen.SetCell(x, y, fillStyle, ' ')
@@ -231,37 +739,25 @@ func (r *Renderer) RenderPause(state protocol.PauseState) {
 
 	if state.WaitingForServe {
 		// Show which team should serve
-		var teamName string
-		var teamStyle tcell.Style
-		if state.ServingTeam == protocol.TeamLeft {
-			teamName = "LEFT TEAM"
-			teamStyle = tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorRed).Bold(true)
-		} else {
-			teamName = "RIGHT TEAM"
-			teamStyle = tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorBlue).Bold(true)
-		}
+		teamStyle := tcell.StyleDefault.Background(theme.Bar).Foreground(teamColor(state.ServingTeam)).Bold(true)
 
-		serveText := fmt.Sprintf("%s SERVE", teamName)
+		serveText := fmt.Sprintf("%s TEAM SERVE", teamName(state.ServingTeam))
 		serveX := (screenW - len(serveText)) / 2
 		r.screen.DrawText(serveX, boxY+2, serveText, teamStyle)
 
 		instructText := "Press ENTER to serve"
 		instructX := (screenW - len(instructText)) / 2
-		instructStyle := tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorGreen)
+		instructStyle := tcell.StyleDefault.Background(theme.Bar).Foreground(tcell.ColorGreen)
 		r.screen.DrawText(instructX, boxY+4, instructText, instructStyle)
 	} else {
 		// Brief pause after score - show who scored
-		var scorerName string
-		var scorerStyle tcell.Style
-		if state.LastScorer == protocol.TeamLeft {
-			scorerName = "LEFT TEAM"
-			scorerStyle = tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorRed).Bold(true)
-		} else {
-			scorerName = "RIGHT TEAM"
-			scorerStyle = tcell.StyleDefault.Background(tcell.ColorDarkGray).Foreground(tcell.ColorBlue).Bold(true)
-		}
+		scorerName := teamName(state.LastScorer) + " TEAM"
+		scorerStyle := tcell.StyleDefault.Background(theme.Bar).Foreground(teamColor(state.LastScorer)).Bold(true)
 
 		scoreMsg := fmt.Sprintf("%s SCORES!", scorerName)
+		if state.SetEnded {
+			scoreMsg = fmt.Sprintf("%s WINS THE SET!", scorerName)
+		}
 		scoreMsgX := (screenW - len(scoreMsg)) / 2
 		r.screen.DrawText(scoreMsgX, boxY+3, scoreMsg, scorerStyle)
 	}
@@ -269,7 +765,74 @@ func (r *Renderer) RenderPause(state protocol.PauseState) {
 	r.screen.Show()
 }
 
-// RenderGameOver displays the game over screen
+// renderPausedBox shows who paused the match and how the resume vote is going
+func (r *Renderer) renderPausedBox(state protocol.PauseState) {
+	screenW, screenH := r.screen.Size()
+	boxW := 40
+	boxH := 7
+	boxX := (screenW - boxW) / 2
+	boxY := (screenH - boxH) / 2
+	r.screen.DrawBox(boxX, boxY, boxW, boxH, tcell.StyleDefault.Foreground(tcell.ColorWhite))
+	fillStyle := tcell.StyleDefault.Background(theme.Bar)
+	for y := boxY + 1; y < boxY+boxH-1; y++ {
+		for x := boxX + 1; x < boxX+boxW-1; x++ {
+			r.screen.SetCell(x, y, fillStyle, ' ')
+		}
+	}
+
+	pausedText := fmt.Sprintf("PAUSED BY %s", strings.ToUpper(state.PausedBy))
+	pausedStyle := fillStyle.Foreground(teamColor(state.PausedTeam)).Bold(true)
+	r.screen.DrawText((screenW-len(pausedText))/2, boxY+1, pausedText, pausedStyle)
+
+	if state.ResumeSecondsLeft > 0 {
+		resumeText := fmt.Sprintf("Resuming in %d", state.ResumeSecondsLeft)
+		r.screen.DrawText((screenW-len(resumeText))/2, boxY+3, resumeText, fillStyle.Foreground(tcell.ColorYellow).Bold(true))
+	} else {
+		voteText := fmt.Sprintf("Press P to vote to resume (%d/%d)", state.ResumeVotes, state.VotesNeeded)
+		r.screen.DrawText((screenW-len(voteText))/2, boxY+3, voteText, fillStyle.Foreground(tcell.ColorGreen))
+	}
+
+	leftText := fmt.Sprintf("%s team pauses left: %d", teamName(state.PausedTeam), state.PausesLeft)
+	r.screen.DrawText((screenW-len(leftText))/2, boxY+5, leftText, fillStyle.Foreground(theme.BarText))
+}
+
+// renderServeBox shows who serves above the court, leaving the paddles
+// visible so the server can aim
+func (r *Renderer) renderServeBox(state protocol.PauseState) {
+	screenW, _ := r.screen.Size()
+	boxW := 40
+	boxH := 6
+	boxX := (screenW - boxW) / 2
+	boxY := 2
+	r.screen.DrawBox(boxX, boxY, boxW, boxH, tcell.StyleDefault.Foreground(tcell.ColorWhite))
+	fillStyle := tcell.StyleDefault.Background(theme.Bar)
+	for y := boxY + 1; y < boxY+boxH-1; y++ {
+		for x := boxX + 1; x < boxX+boxW-1; x++ {
+			r.screen.SetCell(x, y, fillStyle, ' ')
+		}
+	}
+
+	serveText := fmt.Sprintf("%s TEAM SERVE", teamName(state.ServingTeam))
+	if state.ServerName != "" {
+		serveText = fmt.Sprintf("%s SERVES FOR %s", strings.ToUpper(state.ServerName), teamName(state.ServingTeam))
+	}
+	teamStyle := fillStyle.Foreground(teamColor(state.ServingTeam)).Bold(true)
+	r.screen.DrawText((screenW-len(serveText))/2, boxY+1, serveText, teamStyle)
+
+	instructText := "Aim with your paddle, ENTER to serve"
+	r.screen.DrawText((screenW-len(instructText))/2, boxY+2, instructText, fillStyle.Foreground(tcell.ColorGreen))
+
+	if state.ServeSecondsLeft > 0 {
+		clockText := fmt.Sprintf("Auto-serve in %d", state.ServeSecondsLeft)
+		clockStyle := fillStyle.Foreground(theme.BarText)
+		if state.ServeSecondsLeft <= 3 {
+			clockStyle = fillStyle.Foreground(tcell.ColorRed).Bold(true)
+		}
+		r.screen.DrawText((screenW-len(clockText))/2, boxY+4, clockText, clockStyle)
+	}
+}
+
+// RenderGameOver displays the result and the match stats
 func (r *Renderer) RenderGameOver(state protocol.GameOverState) {
 	r.screen.Clear()
 	screenW, screenH := r.screen.Size()
@@ -278,36 +841,175 @@ func (r *Renderer) RenderGameOver(state protocol.GameOverState) {
 	title := "=== GAME OVER ==="
 	titleX := (screenW - len(title)) / 2
 	titleStyle := tcell.StyleDefault.Bold(true).Foreground(tcell.ColorYellow)
-	r.screen.DrawText(titleX, screenH/2-4, title, titleStyle)
+	r.screen.DrawText(titleX, 2, title, titleStyle)
 
 	// Final score
 	scoreText := fmt.Sprintf("Final Score: %d - %d", state.LeftScore, state.RightScore)
+	if len(state.Sides) > 0 {
+		var scores []string
+		for _, side := range state.Sides {
+			scores = append(scores, fmt.Sprintf("%s %d", teamName(side.Team), side.Score))
+		}
+		scoreText = "Final Score: " + strings.Join(scores, " | ")
+	}
 	scoreX := (screenW - len(scoreText)) / 2
-	r.screen.DrawText(scoreX, screenH/2-1, scoreText, tcell.StyleDefault.Foreground(tcell.ColorWhite))
+	r.screen.DrawText(scoreX, 4, scoreText, tcell.StyleDefault.Foreground(tcell.ColorWhite))
 
 	// Winner announcement
-	var winner string
-	var winnerStyle tcell.Style
-	if state.WinningTeam == protocol.TeamLeft {
-		winner = "LEFT TEAM WINS!"
-		winnerStyle = tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true)
-	} else {
-		winner = "RIGHT TEAM WINS!"
-		winnerStyle = tcell.StyleDefault.Foreground(tcell.ColorBlue).Bold(true)
-	}
+	winner := fmt.Sprintf("%s TEAM WINS!", teamName(state.WinningTeam))
+	winnerStyle := tcell.StyleDefault.Foreground(teamColor(state.WinningTeam)).Bold(true)
 	winnerX := (screenW - len(winner)) / 2
-	r.screen.DrawText(winnerX, screenH/2+1, winner, winnerStyle)
+	r.screen.DrawText(winnerX, 5, winner, winnerStyle)
+
+	// How the match was decided
+	decisionText := decisionDescription(state.Decision)
+	if state.Match.Sets > 1 {
+		decisionText = fmt.Sprintf("%s (%d - %d)", decisionText, state.Match.LeftSets, state.Match.RightSets)
+	}
+	decisionX := (screenW - len(decisionText)) / 2
+	r.screen.DrawText(decisionX, 6, decisionText, tcell.StyleDefault.Foreground(tcell.ColorGray))
+
+	// Player stats, with rating changes
+	r.renderStatsTable(state, 8, screenW)
+
+	// Results that are lost when the game closes
+	for i, saveErr := range state.SaveErrors {
+		warning := "Warning: " + saveErr
+		warningX := max(0, (screenW-len(warning))/2)
+		r.screen.DrawText(warningX, screenH-3-len(state.SaveErrors)+i, warning, tcell.StyleDefault.Foreground(tcell.ColorRed))
+	}
 
 	// Instructions
-	rematchText := "Press ENTER for rematch | Press 'q' to quit"
-	rematchX := (screenW - len(rematchText)) / 2
-	r.screen.DrawText(rematchX, screenH/2+4, rematchText, tcell.StyleDefault.Foreground(tcell.ColorGreen))
+	continueText := "Press ENTER to continue | Press 'q' to quit"
+	continueX := (screenW - len(continueText)) / 2
+	r.screen.DrawText(continueX, screenH-2, continueText, tcell.StyleDefault.Foreground(tcell.ColorGreen))
 
 	r.screen.Show()
 }
 
+// renderStatsTable draws one row of match stats per player, then the
+// records of the match
+func (r *Renderer) renderStatsTable(state protocol.GameOverState, y, screenW int) {
+	ratings := make(map[string]protocol.RatingChange)
+	for _, change := range state.Ratings {
+		ratings[change.Name] = change
+	}
+
+	header := fmt.Sprintf("%-14s %5s %5s %5s %5s %7s %9s  %s", "Player", "Hits", "Saves", "Goals", "Own", "Let in", "Top speed", "Rating")
+	tableX := max(0, (screenW-len(header)-6)/2)
+	r.screen.DrawText(tableX, y, header, tcell.StyleDefault.Foreground(tcell.ColorGray).Bold(true))
+
+	for i, stats := range state.Stats {
+		row := fmt.Sprintf("%-14s %5d %5d %5d %5d %7d %9.1f",
+			truncate(stats.Name, 14), stats.Hits, stats.Saves, stats.Goals, stats.OwnGoals, stats.Conceded, stats.TopSpeed)
+		r.screen.DrawText(tableX, y+1+i, row, tcell.StyleDefault.Foreground(teamColor(stats.Team)))
+		if change, ok := ratings[stats.Name]; ok {
+			delta := change.After - change.Before
+			ratingText := fmt.Sprintf("%d %s", change.After, ratingDelta(delta))
+			r.screen.DrawText(tableX+len(row)+2, y+1+i, ratingText, ratingStyle(delta))
+		}
+	}
+
+	recordText := fmt.Sprintf("Longest rally: %d hits | Top speed: %.1f cells/s", state.LongestRally, state.TopSpeed)
+	recordX := (screenW - len(recordText)) / 2
+	r.screen.DrawText(recordX, y+len(state.Stats)+2, recordText, tcell.StyleDefault.Foreground(tcell.ColorTeal))
+}
+
+// truncate shortens a name to fit a table column
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// decisionDescription explains how a match was decided
+func decisionDescription(decision protocol.MatchDecision) string {
+	switch decision {
+	case protocol.DecidedByTwoPointLead:
+		return "Won by two after deuce"
+	case protocol.DecidedByTime:
+		return "Ahead when time ran out"
+	case protocol.DecidedByOvertime:
+		return "Sudden-death goal in overtime"
+	case protocol.DecidedBySets:
+		return "Won on sets"
+	case protocol.DecidedByLastStanding:
+		return "Last team standing"
+	}
+	return "First to the target score"
+}
+
+// ratingDelta formats a rating change as (+12) or (-12)
+func ratingDelta(delta int) string {
+	if delta >= 0 {
+		return fmt.Sprintf("(+%d)", delta)
+	}
+	return fmt.Sprintf("(%d)", delta)
+}
+
+// ratingStyle colors a rating change by whether it is a gain or a loss
+func ratingStyle(delta int) tcell.Style {
+	switch {
+	case delta > 0:
+		return tcell.StyleDefault.Foreground(theme.RatingUp)
+	case delta < 0:
+		return tcell.StyleDefault.Foreground(theme.RatingDown)
+	}
+	return tcell.StyleDefault.Foreground(tcell.ColorGray)
+}
+
+// preferenceLabel returns the lobby tag for a player's team choice
+func preferenceLabel(pref protocol.TeamPreference) (string, tcell.Style) {
+	switch pref {
+	case protocol.PreferLeft:
+		return teamLabel(protocol.TeamLeft)
+	case protocol.PreferRight:
+		return teamLabel(protocol.TeamRight)
+	case protocol.PreferTop:
+		return teamLabel(protocol.TeamTop)
+	case protocol.PreferBottom:
+		return teamLabel(protocol.TeamBottom)
+	}
+	return "random", tcell.StyleDefault.Foreground(tcell.ColorGray)
+}
+
+// entrantLabel returns the tag of a player registered in a tournament team
+func entrantLabel(team int) (string, tcell.Style) {
+	if team == 0 {
+		return "any team", tcell.StyleDefault.Foreground(tcell.ColorGray)
+	}
+	return fmt.Sprintf("TEAM %d", team), tcell.StyleDefault.Foreground(tcell.ColorWhite).Bold(true)
+}
+
+// queueLabel returns the tag of a player waiting in the winner-stays-on line
+func queueLabel(pos int) (string, tcell.Style) {
+	return fmt.Sprintf("LINE #%d", pos), tcell.StyleDefault.Foreground(tcell.ColorGray)
+}
+
+// teamLabel returns the lobby tag for a team
+func teamLabel(team protocol.Team) (string, tcell.Style) {
+	style := tcell.StyleDefault.Foreground(teamColor(team)).Bold(true)
+	switch team {
+	case protocol.TeamLeft:
+		return "< LEFT", style
+	case protocol.TeamTop:
+		return "^ TOP", style
+	case protocol.TeamBottom:
+		return "v BOTTOM", style
+	}
+	return "RIGHT >", style
+}
+
 // RenderRematch displays the rematch screen
 func (r *Renderer) RenderRematch(state protocol.RematchState) {
+	// Tournaments show the bracket between matches instead
+	if state.Bracket != nil {
+		r.RenderTournament(state)
+		return
+	}
+
 	r.screen.Clear()
 	screenW, screenH := r.screen.Size()
 
@@ -335,9 +1037,33 @@ func (r *Renderer) RenderRematch(state protocol.RematchState) {
 
 		playerStyle := GetPlayerStyle(player.Color)
 		playerText := fmt.Sprintf("  %s ", player.Name)
+		if player.IsBot {
+			playerText = fmt.Sprintf("  %s [BOT] ", player.Name)
+		}
 		y := listY + 2 + i
 		r.screen.DrawText(4, y, playerText, playerStyle)
 		r.screen.DrawText(4+len(playerText), y, statusIcon, statusStyle)
+		if player.Rating > 0 {
+			ratingText := fmt.Sprintf("%d %s", player.Rating, ratingDelta(player.RatingDelta))
+			r.screen.DrawText(lobbyTeamColumn+10, y, ratingText, ratingStyle(player.RatingDelta))
+		}
+		if player.QueuePos > 0 {
+			queueText, queueStyle := queueLabel(player.QueuePos)
+			r.screen.DrawText(lobbyTeamColumn, y, queueText, queueStyle)
+		} else if !state.Reshuffle {
+			teamText, teamStyle := teamLabel(player.Team)
+			r.screen.DrawText(lobbyTeamColumn, y, teamText, teamStyle)
+		}
+	}
+
+	// Teams carry over unless someone asked for a reshuffle
+	teamsY := listY + 3 + len(state.Players)
+	if state.King > 0 {
+		r.screen.DrawText(4, teamsY, "Winners stay on, the losers went to the back of the line", tcell.StyleDefault.Foreground(tcell.ColorGray))
+	} else if state.Reshuffle {
+		r.screen.DrawText(4, teamsY, "Teams will be reshuffled", tcell.StyleDefault.Foreground(tcell.ColorYellow))
+	} else {
+		r.screen.DrawText(4, teamsY, "Same teams as last match (R to reshuffle)", tcell.StyleDefault.Foreground(tcell.ColorGray))
 	}
 
 	// Instructions
@@ -419,6 +1145,29 @@ func (r *Renderer) RenderConnecting(addr string) {
 	r.screen.Show()
 }
 
+// RenderTooSmall replaces any screen while the terminal is below the minimum size
+func (r *Renderer) RenderTooSmall(width, height, minWidth, minHeight int) {
+	r.screen.Clear()
+
+	lines := []string{
+		"Terminal too small",
+		fmt.Sprintf("%dx%d, need %dx%d", width, height, minWidth, minHeight),
+		"Enlarge the window",
+	}
+	styles := []tcell.Style{
+		tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true),
+		tcell.StyleDefault.Foreground(tcell.ColorWhite),
+		tcell.StyleDefault.Foreground(tcell.ColorGray),
+	}
+	top := max(0, (height-len(lines))/2)
+	for i, line := range lines {
+		line = truncate(line, width)
+		r.screen.DrawText(max(0, (width-len(line))/2), top+i, line, styles[i])
+	}
+
+	r.screen.Show()
+}
+
 // RenderError displays an error screen
 func (r *Renderer) RenderError(err string) {
 	r.screen.Clear()
@@ -2,17 +2,8 @@ package ui
 
 import "github.com/gdamore/tcell/v2"
 
-// PlayerColors defines colors for up to 8 players
-var PlayerColors = []tcell.Color{
-	tcell.ColorRed,
-	tcell.ColorBlue,
-	tcell.ColorGreen,
-	tcell.ColorYellow,
-	tcell.ColorPurple,
-	tcell.ColorOrange,
-	tcell.ColorTeal,
-	tcell.ColorFuchsia,
-}
+// PlayerColors defines colors for the players, from the theme in use
+var PlayerColors = ClassicTheme().Players
 
 type Screen struct {
 	screen tcell.Screen
@@ -49,77 +40,91 @@ func (s *Screen) Fini() {
 	s.screen.Fini()
 }
 
+func (s *Screen) Colors() int {
+	return s.screen.Colors()
+}
+
+// SetCell draws a cell. A style without a background keeps the one already
+// there, so nothing drawn on the court punches a hole in its shading.
 func (s *Screen) SetCell(x, y int, style tcell.Style, r rune) {
+	if _, bg, _ := style.Decompose(); bg == tcell.ColorDefault {
+		_, _, under, _ := s.screen.GetContent(x, y)
+		_, bg, _ = under.Decompose()
+		style = style.Background(bg)
+	}
 	s.screen.SetContent(x, y, r, nil, style)
 }
 
 func (s *Screen) DrawText(x, y int, text string, style tcell.Style) {
 	for i, r := range text {
-		s.screen.SetContent(x+i, y, r, nil, style)
+		s.SetCell(x+i, y, style, r)
 	}
 }
 
 func (s *Screen) DrawBox(x, y, w, h int, style tcell.Style) {
-	const (
-		topLeft     = '┌'
-		topRight    = '┐'
-		bottomLeft  = '└'
-		bottomRight = '┘'
-		horizontal  = '─'
-		vertical    = '│'
-	)
-
-	s.screen.SetContent(x, y, topLeft, nil, style)
-	s.screen.SetContent(x+w-1, y, topRight, nil, style)
-	s.screen.SetContent(x, y+h-1, bottomLeft, nil, style)
-	s.screen.SetContent(x+w-1, y+h-1, bottomRight, nil, style)
+	s.SetCell(x, y, style, glyphs.BoxTopLeft)
+	s.SetCell(x+w-1, y, style, glyphs.BoxTopRight)
+	s.SetCell(x, y+h-1, style, glyphs.BoxBottomLeft)
+	s.SetCell(x+w-1, y+h-1, style, glyphs.BoxBottomRight)
 
 	for i := x + 1; i < x+w-1; i++ {
-		s.screen.SetContent(i, y, horizontal, nil, style)
-		s.screen.SetContent(i, y+h-1, horizontal, nil, style)
+		s.SetCell(i, y, style, glyphs.BoxHorizontal)
+		s.SetCell(i, y+h-1, style, glyphs.BoxHorizontal)
 	}
 
 	for j := y + 1; j < y+h-1; j++ {
-		s.screen.SetContent(x, j, vertical, nil, style)
-		s.screen.SetContent(x+w-1, j, vertical, nil, style)
+		s.SetCell(x, j, style, glyphs.BoxVertical)
+		s.SetCell(x+w-1, j, style, glyphs.BoxVertical)
 	}
 }
 
 func (s *Screen) FillRect(x, y, w, h int, style tcell.Style, r rune) {
 	for dy := 0; dy < h; dy++ {
 		for dx := 0; dx < w; dx++ {
-			s.screen.SetContent(x+dx, y+dy, r, nil, style)
+			s.SetCell(x+dx, y+dy, style, r)
 		}
 	}
 }
 
 func (s *Screen) DrawVerticalLine(x, y1, y2 int, style tcell.Style, r rune) {
 	for y := y1; y <= y2; y++ {
-		s.screen.SetContent(x, y, r, nil, style)
+		s.SetCell(x, y, style, r)
 	}
 }
 
+func (s *Screen) DrawHorizontalLine(x1, x2, y int, style tcell.Style, r rune) {
+	for x := x1; x <= x2; x++ {
+		s.SetCell(x, y, style, r)
+	}
+}
+
+func (s *Screen) CanDisplay(r rune) bool {
+	return s.screen.CanDisplay(r, false)
+}
+
 func (s *Screen) PollEvent() tcell.Event {
 	return s.screen.PollEvent()
 }
 
 func GetPlayerStyle(colorIndex int) tcell.Style {
-	if colorIndex < 0 || colorIndex >= len(PlayerColors) {
+	if colorIndex < 0 || len(PlayerColors) == 0 {
 		return tcell.StyleDefault
 	}
-	return tcell.StyleDefault.Foreground(PlayerColors[colorIndex])
+	return tcell.StyleDefault.Foreground(GetPlayerColor(colorIndex))
 }
 
 func GetPlayerBgStyle(colorIndex int) tcell.Style {
-	if colorIndex < 0 || colorIndex >= len(PlayerColors) {
+	if colorIndex < 0 || len(PlayerColors) == 0 {
 		return tcell.StyleDefault
 	}
-	return tcell.StyleDefault.Background(PlayerColors[colorIndex])
+	return tcell.StyleDefault.Background(GetPlayerColor(colorIndex))
 }
 
+// GetPlayerColor returns a player's color. Themes with fewer colors than
+// players reuse them.
 func GetPlayerColor(colorIndex int) tcell.Color {
-	if colorIndex < 0 || colorIndex >= len(PlayerColors) {
+	if colorIndex < 0 || len(PlayerColors) == 0 {
 		return tcell.ColorWhite
 	}
-	return PlayerColors[colorIndex]
+	return PlayerColors[colorIndex%len(PlayerColors)]
 }
@@ -0,0 +1,356 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Theme is the set of colors every screen is drawn with
+type Theme struct {
+	Name       string
+	Court      tcell.Color // Court background
+	CourtEdge  tcell.Color // Court background at the top and bottom edges on truecolor terminals, ColorDefault for a flat court
+	Letterbox  tcell.Color // Shading around a court that doesn't fill the screen
+	CenterLine tcell.Color
+	Walls      tcell.Color // Map blocks and walls
+	Bumpers    tcell.Color // Map bumpers
+	Bar        tcell.Color // Scoreboard, status bar and message box background
+	BarText    tcell.Color
+	Teams      map[protocol.Team]tcell.Color
+	Players    []tcell.Color
+	PowerUps   map[protocol.PowerUpKind]tcell.Color
+
+	// Ball colors by how much it spins
+	Ball, BallSpin, BallStrongSpin tcell.Color
+
+	Flash      tcell.Color // Paddle that just hit the ball
+	Leader     tcell.Color // Top row of the leaderboard
+	RatingUp   tcell.Color
+	RatingDown tcell.Color
+}
+
+// ClassicTheme returns the original colors
+func ClassicTheme() Theme {
+	return Theme{
+		Name:       "classic",
+		Court:      tcell.ColorBlack,
+		CourtEdge:  tcell.NewHexColor(0x0c1a2a),
+		Letterbox:  tcell.ColorDarkGray,
+		CenterLine: tcell.ColorDarkGray,
+		Walls:      tcell.ColorGray,
+		Bumpers:    tcell.ColorYellow,
+		Bar:        tcell.ColorDarkGray,
+		BarText:    tcell.ColorWhite,
+		Teams: map[protocol.Team]tcell.Color{
+			protocol.TeamLeft:   tcell.ColorRed,
+			protocol.TeamRight:  tcell.ColorBlue,
+			protocol.TeamTop:    tcell.ColorGreen,
+			protocol.TeamBottom: tcell.ColorYellow,
+		},
+		Players: []tcell.Color{
+			tcell.ColorRed,
+			tcell.ColorBlue,
+			tcell.ColorGreen,
+			tcell.ColorYellow,
+			tcell.ColorPurple,
+			tcell.ColorOrange,
+			tcell.ColorTeal,
+			tcell.ColorFuchsia,
+		},
+		PowerUps:       PowerUpColors,
+		Ball:           tcell.ColorWhite,
+		BallSpin:       tcell.ColorOrange,
+		BallStrongSpin: tcell.ColorOrangeRed,
+		Flash:          tcell.ColorWhite,
+		Leader:         tcell.ColorYellow,
+		RatingUp:       tcell.ColorGreen,
+		RatingDown:     tcell.ColorRed,
+	}
+}
+
+// ThemePresets are the built-in themes that --theme accepts by name
+var ThemePresets = map[string]Theme{
+	"classic": ClassicTheme(),
+	// Okabe-Ito colors, told apart with any kind of color blindness
+	"colorblind": {
+		Name:       "colorblind",
+		Court:      tcell.ColorBlack,
+		CourtEdge:  tcell.NewHexColor(0x1a1a1a),
+		Letterbox:  tcell.NewHexColor(0x666666),
+		CenterLine: tcell.NewHexColor(0x808080),
+		Walls:      tcell.NewHexColor(0x999999),
+		Bumpers:    tcell.NewHexColor(0xe69f00),
+		Bar:        tcell.NewHexColor(0x333333),
+		BarText:    tcell.ColorWhite,
+		Teams: map[protocol.Team]tcell.Color{
+			protocol.TeamLeft:   tcell.NewHexColor(0xe69f00), // Orange
+			protocol.TeamRight:  tcell.NewHexColor(0x56b4e9), // Sky blue
+			protocol.TeamTop:    tcell.NewHexColor(0x009e73), // Bluish green
+			protocol.TeamBottom: tcell.NewHexColor(0xf0e442), // Yellow
+		},
+		Players: []tcell.Color{
+			tcell.NewHexColor(0xe69f00),
+			tcell.NewHexColor(0x56b4e9),
+			tcell.NewHexColor(0x009e73),
+			tcell.NewHexColor(0xf0e442),
+			tcell.NewHexColor(0x0072b2),
+			tcell.NewHexColor(0xd55e00),
+			tcell.NewHexColor(0xcc79a7),
+			tcell.ColorWhite,
+		},
+		PowerUps: map[protocol.PowerUpKind]tcell.Color{
+			protocol.PowerUpGrow:      tcell.NewHexColor(0x56b4e9), // Blue and vermillion rather than green and red
+			protocol.PowerUpShrink:    tcell.NewHexColor(0xd55e00),
+			protocol.PowerUpSpeed:     tcell.NewHexColor(0xf0e442),
+			protocol.PowerUpReverse:   tcell.NewHexColor(0xcc79a7),
+			protocol.PowerUpExtraBall: tcell.ColorWhite,
+			protocol.PowerUpShield:    tcell.NewHexColor(0x0072b2),
+		},
+		Ball:           tcell.ColorWhite,
+		BallSpin:       tcell.NewHexColor(0xf0e442), // Yellow
+		BallStrongSpin: tcell.NewHexColor(0xd55e00), // Vermillion
+		Flash:          tcell.ColorWhite,
+		Leader:         tcell.NewHexColor(0xf0e442),
+		RatingUp:       tcell.NewHexColor(0x56b4e9), // Blue and vermillion rather than green and red
+		RatingDown:     tcell.NewHexColor(0xd55e00),
+	},
+	// Bright colors on plain black, for low vision or washed out screens
+	"contrast": {
+		Name:       "contrast",
+		Court:      tcell.ColorBlack,
+		CourtEdge:  tcell.ColorDefault,
+		Letterbox:  tcell.ColorGray,
+		CenterLine: tcell.ColorWhite,
+		Walls:      tcell.ColorWhite,
+		Bumpers:    tcell.ColorOrange,
+		Bar:        tcell.ColorBlack,
+		BarText:    tcell.ColorWhite,
+		Teams: map[protocol.Team]tcell.Color{
+			protocol.TeamLeft:   tcell.ColorYellow,
+			protocol.TeamRight:  tcell.ColorAqua,
+			protocol.TeamTop:    tcell.ColorLime,
+			protocol.TeamBottom: tcell.ColorFuchsia,
+		},
+		Players: []tcell.Color{
+			tcell.ColorYellow,
+			tcell.ColorAqua,
+			tcell.ColorLime,
+			tcell.ColorFuchsia,
+			tcell.ColorWhite,
+			tcell.ColorOrange,
+			tcell.ColorRed,
+			tcell.ColorDodgerBlue,
+		},
+		PowerUps: map[protocol.PowerUpKind]tcell.Color{
+			protocol.PowerUpGrow:      tcell.ColorAqua,
+			protocol.PowerUpShrink:    tcell.ColorRed,
+			protocol.PowerUpSpeed:     tcell.ColorYellow,
+			protocol.PowerUpReverse:   tcell.ColorFuchsia,
+			protocol.PowerUpExtraBall: tcell.ColorWhite,
+			protocol.PowerUpShield:    tcell.ColorLime,
+		},
+		Ball:           tcell.ColorWhite,
+		BallSpin:       tcell.ColorYellow,
+		BallStrongSpin: tcell.ColorFuchsia,
+		Flash:          tcell.ColorWhite,
+		Leader:         tcell.ColorYellow,
+		RatingUp:       tcell.ColorLime,
+		RatingDown:     tcell.ColorRed,
+	},
+}
+
+// theme is the theme in use
+var theme = ClassicTheme()
+
+// UseTheme switches every screen to the theme's colors
+func UseTheme(t Theme) {
+	theme = t
+	PlayerColors = t.Players
+}
+
+// LoadTheme returns a preset by name, or reads a theme file
+func LoadTheme(nameOrPath string) (Theme, error) {
+	if t, ok := ThemePresets[nameOrPath]; ok {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to read theme: %w", err)
+	}
+	return ParseTheme(data)
+}
+
+// themeFile is the JSON form of a theme. Colors are names or #rrggbb, and
+// the ones left out keep their classic value.
+type themeFile struct {
+	Name           string            `json:"name"`
+	Court          string            `json:"court"`
+	CourtEdge      string            `json:"court_edge"` // "none" for a flat court
+	Letterbox      string            `json:"letterbox"`
+	CenterLine     string            `json:"center_line"`
+	Walls          string            `json:"walls"`
+	Bumpers        string            `json:"bumpers"`
+	Bar            string            `json:"bar"`
+	BarText        string            `json:"bar_text"`
+	Left           string            `json:"left"`
+	Right          string            `json:"right"`
+	Top            string            `json:"top"`
+	Bottom         string            `json:"bottom"`
+	Players        []string          `json:"players"`
+	PowerUps       map[string]string `json:"power_ups"` // By power-up name, like "grow"
+	Ball           string            `json:"ball"`
+	BallSpin       string            `json:"ball_spin"`
+	BallStrongSpin string            `json:"ball_strong_spin"`
+	Flash          string            `json:"flash"`
+	Leader         string            `json:"leader"`
+	RatingUp       string            `json:"rating_up"`
+	RatingDown     string            `json:"rating_down"`
+}
+
+// powerUpKeys names the power-ups in theme files
+var powerUpKeys = map[string]protocol.PowerUpKind{
+	"grow":       protocol.PowerUpGrow,
+	"shrink":     protocol.PowerUpShrink,
+	"speed":      protocol.PowerUpSpeed,
+	"reverse":    protocol.PowerUpReverse,
+	"extra_ball": protocol.PowerUpExtraBall,
+	"shield":     protocol.PowerUpShield,
+}
+
+// ParseTheme decodes a JSON theme on top of the classic colors
+func ParseTheme(data []byte) (Theme, error) {
+	var file themeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Theme{}, fmt.Errorf("failed to parse theme: %w", err)
+	}
+	if file.Name == "" {
+		return Theme{}, errors.New("themes need a name")
+	}
+
+	t := ClassicTheme()
+	t.Name = file.Name
+	left, right := t.Teams[protocol.TeamLeft], t.Teams[protocol.TeamRight]
+	top, bottom := t.Teams[protocol.TeamTop], t.Teams[protocol.TeamBottom]
+	fields := []struct {
+		key   string
+		value string
+		color *tcell.Color
+	}{
+		{"court", file.Court, &t.Court},
+		{"letterbox", file.Letterbox, &t.Letterbox},
+		{"center_line", file.CenterLine, &t.CenterLine},
+		{"walls", file.Walls, &t.Walls},
+		{"bumpers", file.Bumpers, &t.Bumpers},
+		{"bar", file.Bar, &t.Bar},
+		{"bar_text", file.BarText, &t.BarText},
+		{"left", file.Left, &left},
+		{"right", file.Right, &right},
+		{"top", file.Top, &top},
+		{"bottom", file.Bottom, &bottom},
+		{"ball", file.Ball, &t.Ball},
+		{"ball_spin", file.BallSpin, &t.BallSpin},
+		{"ball_strong_spin", file.BallStrongSpin, &t.BallStrongSpin},
+		{"flash", file.Flash, &t.Flash},
+		{"leader", file.Leader, &t.Leader},
+		{"rating_up", file.RatingUp, &t.RatingUp},
+		{"rating_down", file.RatingDown, &t.RatingDown},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		color, err := parseColor(f.key, f.value)
+		if err != nil {
+			return Theme{}, err
+		}
+		*f.color = color
+	}
+	t.Teams = map[protocol.Team]tcell.Color{
+		protocol.TeamLeft:   left,
+		protocol.TeamRight:  right,
+		protocol.TeamTop:    top,
+		protocol.TeamBottom: bottom,
+	}
+
+	switch file.CourtEdge {
+	case "":
+	case "none":
+		t.CourtEdge = tcell.ColorDefault
+	default:
+		color, err := parseColor("court_edge", file.CourtEdge)
+		if err != nil {
+			return Theme{}, err
+		}
+		t.CourtEdge = color
+	}
+
+	if len(file.PowerUps) > 0 {
+		powerUps := make(map[protocol.PowerUpKind]tcell.Color, len(t.PowerUps))
+		for kind, color := range t.PowerUps {
+			powerUps[kind] = color
+		}
+		for name, value := range file.PowerUps {
+			kind, ok := powerUpKeys[name]
+			if !ok {
+				return Theme{}, fmt.Errorf("power_ups: unknown power-up %q", name)
+			}
+			color, err := parseColor("power_ups."+name, value)
+			if err != nil {
+				return Theme{}, err
+			}
+			powerUps[kind] = color
+		}
+		t.PowerUps = powerUps
+	}
+
+	if file.Players != nil {
+		if len(file.Players) == 0 {
+			return Theme{}, errors.New("players needs at least one color")
+		}
+		t.Players = make([]tcell.Color, len(file.Players))
+		for i, value := range file.Players {
+			color, err := parseColor(fmt.Sprintf("players[%d]", i), value)
+			if err != nil {
+				return Theme{}, err
+			}
+			t.Players[i] = color
+		}
+	}
+	return t, nil
+}
+
+// parseColor reads a color name or #rrggbb value
+func parseColor(key, value string) (tcell.Color, error) {
+	color := tcell.GetColor(value)
+	if color == tcell.ColorDefault {
+		return tcell.ColorDefault, fmt.Errorf("%s: unknown color %q", key, value)
+	}
+	return color, nil
+}
+
+// blend mixes two colors, from all a at 0 to all b at 1
+func blend(a, b tcell.Color, t float64) tcell.Color {
+	ar, ag, ab := a.RGB()
+	br, bg, bb := b.RGB()
+	mix := func(x, y int32) int32 {
+		return x + int32(math.Round(float64(y-x)*t))
+	}
+	return tcell.NewRGBColor(mix(ar, br), mix(ag, bg), mix(ab, bb))
+}
+
+// courtColor returns the court background on a screen row. Truecolor
+// terminals shade it toward the theme's edge color at the top and bottom.
+func (r *Renderer) courtColor(v courtView, y int) tcell.Color {
+	if !r.truecolor || theme.CourtEdge == tcell.ColorDefault || v.height < 2 {
+		return theme.Court
+	}
+	middle := float64(v.top) + float64(v.height-1)/2
+	return blend(theme.Court, theme.CourtEdge, math.Abs(float64(y)-middle)/(float64(v.height-1)/2))
+}
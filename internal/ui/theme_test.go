@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestLoadTheme_Presets(t *testing.T) {
+	for name := range ThemePresets {
+		theme, err := LoadTheme(name)
+		if err != nil {
+			t.Fatalf("preset %q: %v", name, err)
+		}
+		if theme.Name != name {
+			t.Errorf("expected preset %q to be named after itself, got %q", name, theme.Name)
+		}
+		if len(theme.Teams) != 4 || len(theme.Players) < 8 {
+			t.Errorf("preset %q: expected 4 team and 8 player colors, got %d and %d", name, len(theme.Teams), len(theme.Players))
+		}
+		for _, color := range []tcell.Color{theme.Ball, theme.BallSpin, theme.BallStrongSpin, theme.Flash, theme.Leader, theme.RatingUp, theme.RatingDown} {
+			if color == tcell.ColorDefault {
+				t.Errorf("preset %q: expected every ball, flash, leader and rating color to be set, got %+v", name, theme)
+				break
+			}
+		}
+		if theme.Bumpers == tcell.ColorDefault || len(theme.PowerUps) != len(PowerUpChars) {
+			t.Errorf("preset %q: expected bumper and power-up colors, got %v and %d", name, theme.Bumpers, len(theme.PowerUps))
+		}
+	}
+}
+
+func TestParseTheme_OverridesClassic(t *testing.T) {
+	theme, err := ParseTheme([]byte(`{"name": "night", "court": "#000814", "right": "orange", "court_edge": "none", "players": ["white"], "ball_spin": "yellow", "power_ups": {"grow": "aqua"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if theme.Court != tcell.NewHexColor(0x000814) || theme.Teams[protocol.TeamRight] != tcell.ColorOrange || theme.BallSpin != tcell.ColorYellow {
+		t.Errorf("expected overrides to apply, got %+v", theme)
+	}
+	if theme.Teams[protocol.TeamLeft] != tcell.ColorRed || theme.Walls != tcell.ColorGray {
+		t.Errorf("expected unset colors to keep their classic value, got %+v", theme)
+	}
+	if theme.CourtEdge != tcell.ColorDefault {
+		t.Errorf("expected a flat court, got edge %v", theme.CourtEdge)
+	}
+	if len(theme.Players) != 1 {
+		t.Errorf("expected one player color, got %d", len(theme.Players))
+	}
+	if theme.PowerUps[protocol.PowerUpGrow] != tcell.ColorAqua || theme.PowerUps[protocol.PowerUpShrink] != tcell.ColorRed {
+		t.Errorf("expected grow overridden and shrink kept, got %v", theme.PowerUps)
+	}
+	if PowerUpColors[protocol.PowerUpGrow] != tcell.ColorGreen {
+		t.Errorf("expected the classic power-up colors untouched, got %v", PowerUpColors)
+	}
+}
+
+func TestParseTheme_Invalid(t *testing.T) {
+	for _, data := range []string{
+		`not json`,
+		`{"court": "black"}`,
+		`{"name": "bad", "court": "blurple"}`,
+		`{"name": "bad", "players": []}`,
+		`{"name": "bad", "players": ["red", "nope"]}`,
+		`{"name": "bad", "power_ups": {"teleport": "red"}}`,
+	} {
+		if _, err := ParseTheme([]byte(data)); err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+}
+
+func TestBlend(t *testing.T) {
+	a, b := tcell.NewRGBColor(0, 0, 0), tcell.NewRGBColor(200, 100, 50)
+	if got := blend(a, b, 0.5); got != tcell.NewRGBColor(100, 50, 25) {
+		t.Errorf("expected the halfway color, got %v", got)
+	}
+	if got := blend(a, b, 1); got != b {
+		t.Errorf("expected the end color, got %v", got)
+	}
+}
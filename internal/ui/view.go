@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/diegok/pixpong/internal/protocol"
+	"github.com/gdamore/tcell/v2"
+)
+
+// LetterboxChar fills the screen around a court that doesn't fill it
+const LetterboxChar = '\u2591' // ░
+
+// courtView maps court coordinates onto the screen. The court is scaled the
+// same on both axes, so angles look the same on every terminal, and centered
+// between the status bars with letterbox bars around it.
+type courtView struct {
+	left, top     int // Screen cell of the court's top left corner
+	width, height int // Court size on screen, in cells
+	scale         float64
+}
+
+// newCourtView fits a court into the screen, between the status bars
+func newCourtView(courtW, courtH, screenW, screenH int) courtView {
+	areaW, areaH := screenW, screenH-2
+	if courtW <= 0 || courtH <= 0 || areaW <= 0 || areaH <= 0 {
+		return courtView{top: 1, width: max(areaW, 0), height: max(areaH, 0), scale: 1}
+	}
+
+	scale := math.Min(float64(areaW)/float64(courtW), float64(areaH)/float64(courtH))
+	width := min(areaW, max(1, int(math.Round(float64(courtW)*scale))))
+	height := min(areaH, max(1, int(math.Round(float64(courtH)*scale))))
+	return courtView{
+		left:   (areaW - width) / 2,
+		top:    1 + (areaH-height)/2,
+		width:  width,
+		height: height,
+		scale:  scale,
+	}
+}
+
+// x returns the screen column of a court X position
+func (v courtView) x(courtX float64) int {
+	return v.left + int(math.Round(courtX*v.scale))
+}
+
+// y returns the screen row of a court Y position
+func (v courtView) y(courtY float64) int {
+	return v.top + int(math.Round(courtY*v.scale))
+}
+
+// length returns how many cells a court distance takes on screen
+func (v courtView) length(n float64) int {
+	return int(math.Round(n * v.scale))
+}
+
+// right returns the last screen column of the court
+func (v courtView) right() int {
+	return v.left + v.width - 1
+}
+
+// bottom returns the last screen row of the court
+func (v courtView) bottom() int {
+	return v.top + v.height - 1
+}
+
+// contains returns true if the screen cell is on the court
+func (v courtView) contains(x, y int) bool {
+	return x >= v.left && x <= v.right() && y >= v.top && y <= v.bottom()
+}
+
+// renderCourtArea draws the letterbox bars, the court background and the
+// center line
+func (r *Renderer) renderCourtArea(v courtView, screenW, screenH int) {
+	letterboxStyle := tcell.StyleDefault.Background(theme.Court).Foreground(theme.Letterbox)
+	r.screen.FillRect(0, 1, screenW, screenH-2, letterboxStyle, glyphs.Letterbox)
+
+	// Row by row, for the gradient on truecolor terminals
+	for y := v.top; y <= v.bottom(); y++ {
+		courtStyle := tcell.StyleDefault.Background(r.courtColor(v, y))
+		r.screen.FillRect(v.left, y, v.width, 1, courtStyle, ' ')
+	}
+
+	// Center dashed line
+	centerX := v.left + v.width/2
+	lineStyle := tcell.StyleDefault.Foreground(theme.CenterLine)
+	for y := v.top; y <= v.bottom(); y += 2 {
+		r.screen.SetCell(centerX, y, lineStyle, '|')
+	}
+}
+
+// edgeLine draws a line along one team's edge of the court
+func (r *Renderer) edgeLine(v courtView, team protocol.Team, style tcell.Style, vertical, horizontal rune) {
+	switch team {
+	case protocol.TeamLeft:
+		r.screen.DrawVerticalLine(v.left, v.top, v.bottom(), style, vertical)
+	case protocol.TeamRight:
+		r.screen.DrawVerticalLine(v.right(), v.top, v.bottom(), style, vertical)
+	case protocol.TeamTop:
+		r.screen.DrawHorizontalLine(v.left, v.right(), v.top, style, horizontal)
+	case protocol.TeamBottom:
+		r.screen.DrawHorizontalLine(v.left, v.right(), v.bottom(), style, horizontal)
+	}
+}
@@ -0,0 +1,33 @@
+package ui
+
+import "testing"
+
+func TestNewCourtView_Letterbox(t *testing.T) {
+	// A wide screen keeps the court shape, with bars on both sides
+	v := newCourtView(80, 24, 200, 50)
+	if v.scale != 2 {
+		t.Fatalf("expected scale 2, got %g", v.scale)
+	}
+	if v.width != 160 || v.height != 48 {
+		t.Errorf("expected a 160x48 court, got %dx%d", v.width, v.height)
+	}
+	if v.left != 20 || v.top != 1 {
+		t.Errorf("expected court at 20,1, got %d,%d", v.left, v.top)
+	}
+
+	// A tall screen puts the bars above and below
+	v = newCourtView(80, 24, 80, 60)
+	if v.scale != 1 || v.left != 0 || v.top != 1+(58-24)/2 {
+		t.Errorf("expected a centered unscaled court, got %+v", v)
+	}
+}
+
+func TestCourtView_Mapping(t *testing.T) {
+	v := newCourtView(80, 24, 160, 50)
+	if x, y := v.x(40), v.y(12); x != 80 || y != 1+24 {
+		t.Errorf("expected court center at 80,25, got %d,%d", x, y)
+	}
+	if !v.contains(v.left, v.top) || v.contains(v.left-1, v.top) || v.contains(v.right(), v.bottom()+1) {
+		t.Errorf("contains doesn't match the court area %+v", v)
+	}
+}